@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mije/graphql-tools/pkg/schema/codegen"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/ast"
+)
+
+var codegenCmd = &cobra.Command{
+	Use:   "codegen",
+	Short: "Generate code from schemas",
+}
+
+var codegenGoCmd = &cobra.Command{
+	Use:   "go",
+	Short: "Generate Go types of the schema types",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+
+		scalars, _ := cmd.Flags().GetStringToString("scalar")
+		var buf bytes.Buffer
+		err = codegen.Go(&buf, doc, codegen.GoOptions{
+			Package: cmd.Flag("package").Value.String(),
+			Scalars: scalars,
+		})
+		if err != nil {
+			return err
+		}
+		return writeOutput(cmd, buf.Bytes())
+	},
+}
+
+var codegenTypeScriptCmd = &cobra.Command{
+	Use:   "typescript",
+	Short: "Generate TypeScript types of the schema types and of the operations",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+		ops, err := loadOperations(doc, cmd.Flag("operations").Value.String())
+		if err != nil {
+			return err
+		}
+
+		scalars, _ := cmd.Flags().GetStringToString("scalar")
+		var buf bytes.Buffer
+		err = codegen.TypeScript(&buf, doc, ops, codegen.TypeScriptOptions{Scalars: scalars})
+		if err != nil {
+			return err
+		}
+		return writeOutput(cmd, buf.Bytes())
+	},
+}
+
+var codegenGoClientCmd = &cobra.Command{
+	Use:   "go-client",
+	Short: "Generate a typed Go client of the operations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := cmd.Flag("schema").Value.String()
+		pattern := cmd.Flag("operations").Value.String()
+		if name == "" || pattern == "" {
+			return fmt.Errorf("both schema and operations are required")
+		}
+		doc, err := loadSchema(name, cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+		ops, err := loadOperations(doc, pattern)
+		if err != nil {
+			return err
+		}
+
+		scalars, _ := cmd.Flags().GetStringToString("scalar")
+		var buf bytes.Buffer
+		err = codegen.GoClient(&buf, doc, ops, codegen.GoClientOptions{
+			Package: cmd.Flag("package").Value.String(),
+			Scalars: scalars,
+		})
+		if err != nil {
+			return err
+		}
+		return writeOutput(cmd, buf.Bytes())
+	},
+}
+
+// loadOperations reads the operation documents matching the glob pattern, no pattern means no operations.
+func loadOperations(doc *ast.SchemaDocument, pattern string) (*ast.QueryDocument, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	names, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no operation documents match '%s'", pattern)
+	}
+
+	var sources []*ast.Source
+	for _, name := range names {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &ast.Source{Name: name, Input: string(b)})
+	}
+	return codegen.LoadOperations(doc, sources...)
+}
+
+// writeOutput writes the generated code to the file given by the output flag or to standard output.
+func writeOutput(cmd *cobra.Command, b []byte) error {
+	if out := cmd.Flag("output").Value.String(); out != "" {
+		return ioutil.WriteFile(out, b, 0644)
+	}
+	_, err := os.Stdout.Write(b)
+	return err
+}
+
+func init() {
+	codegenGoCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	codegenGoCmd.Flags().StringP("output", "o", "", "write the code to a file instead of standard output")
+	codegenGoCmd.Flags().String("package", "model", "name of the generated package")
+	codegenGoCmd.Flags().StringToString("scalar", nil, "Go type of a scalar, e.g. DateTime=time.Time")
+
+	codegenTypeScriptCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	codegenTypeScriptCmd.Flags().StringP("output", "o", "", "write the code to a file instead of standard output")
+	codegenTypeScriptCmd.Flags().String("operations", "", "glob pattern of the operation documents, e.g. 'ops/*.graphql'")
+	codegenTypeScriptCmd.Flags().StringToString("scalar", nil, "TypeScript type of a scalar, e.g. DateTime=string")
+
+	codegenGoClientCmd.Flags().String("schema", "", "schema file or endpoint URL")
+	codegenGoClientCmd.Flags().StringP("in", "i", "sdl", "input format of the schema (sdl, introspection)")
+	codegenGoClientCmd.Flags().String("operations", "", "glob pattern of the operation documents, e.g. 'ops/*.graphql'")
+	codegenGoClientCmd.Flags().StringP("output", "o", "", "write the code to a file instead of standard output")
+	codegenGoClientCmd.Flags().String("package", "client", "name of the generated package")
+	codegenGoClientCmd.Flags().StringToString("scalar", nil, "Go type of a scalar, e.g. DateTime=time.Time")
+
+	codegenCmd.AddCommand(codegenGoCmd, codegenTypeScriptCmd, codegenGoClientCmd)
+	schemaCmd.AddCommand(codegenCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/mije/graphql-tools/pkg/schema/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert a schema between SDL and the introspection query result, or to JSON Schema",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := loadSchema(args[0], cmd.Flag("from").Value.String())
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		switch to := cmd.Flag("to").Value.String(); to {
+		case "sdl":
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				return err
+			}
+		case "introspection":
+			s, err := introspection.FromDocument(doc)
+			if err != nil {
+				return err
+			}
+			b, err := introspection.Marshal(s)
+			if err != nil {
+				return err
+			}
+			buf.Write(append(b, '\n'))
+		case "jsonschema":
+			scalars, _ := cmd.Flags().GetStringToString("scalar")
+			outputs, _ := cmd.Flags().GetBool("outputs")
+			opts := jsonschema.Options{Outputs: outputs, Scalars: make(map[string]*jsonschema.Schema)}
+			for name, t := range scalars {
+				// type[:format], e.g. string:date-time
+				parts := strings.SplitN(t, ":", 2)
+				opts.Scalars[name] = &jsonschema.Schema{Type: parts[0]}
+				if len(parts) > 1 {
+					opts.Scalars[name].Format = parts[1]
+				}
+			}
+			s, err := jsonschema.Document(doc, opts)
+			if err != nil {
+				return err
+			}
+			b, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				return err
+			}
+			buf.Write(append(b, '\n'))
+		default:
+			return fmt.Errorf("unsupported output format '%s'", to)
+		}
+
+		if out := cmd.Flag("output").Value.String(); out != "" {
+			return ioutil.WriteFile(out, buf.Bytes(), 0644)
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	},
+}
+
+func init() {
+	convertCmd.Flags().String("from", "sdl", "input format (sdl, introspection)")
+	convertCmd.Flags().String("to", "introspection", "output format (sdl, introspection, jsonschema)")
+	convertCmd.Flags().Bool("outputs", false, "add the output types to the JSON Schema")
+	convertCmd.Flags().StringToString("scalar", nil, "JSON Schema type and format of a scalar, e.g. DateTime=string:date-time")
+	convertCmd.Flags().StringP("output", "o", "", "write the converted schema to a file instead of standard output")
+
+	schemaCmd.AddCommand(convertCmd)
+}
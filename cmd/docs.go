@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/mije/graphql-tools/pkg/schema/docs"
+	"github.com/spf13/cobra"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate HTML or Markdown documentation of a schema",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+
+		files, err := docs.Generate(doc, docs.Options{
+			Format: docs.Format(cmd.Flag("format").Value.String()),
+			Title:  cmd.Flag("title").Value.String(),
+		})
+		if err != nil {
+			return err
+		}
+		return docs.Write(cmd.Flag("out").Value.String(), files)
+	},
+}
+
+func init() {
+	docsCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	docsCmd.Flags().StringP("out", "o", "site", "directory to write the documentation to")
+	docsCmd.Flags().StringP("format", "f", "html", "documentation format (html, markdown)")
+	docsCmd.Flags().String("title", "Schema", "title of the index page")
+
+	schemaCmd.AddCommand(docsCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/spf13/cobra"
+)
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch",
+	Short: "Fetch a schema from a GraphQL endpoint using the introspection query",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endpoint := cmd.Flag("endpoint").Value.String()
+		if endpoint == "" {
+			return fmt.Errorf("missing endpoint")
+		}
+		s, err := fetchSchema(endpoint)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		switch to := cmd.Flag("to").Value.String(); to {
+		case "sdl":
+			doc, err := s.Document()
+			if err != nil {
+				return err
+			}
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				return err
+			}
+		case "introspection":
+			b, err := introspection.Marshal(s)
+			if err != nil {
+				return err
+			}
+			buf.Write(append(b, '\n'))
+		default:
+			return fmt.Errorf("unsupported output format '%s'", to)
+		}
+
+		if out := cmd.Flag("output").Value.String(); out != "" {
+			return ioutil.WriteFile(out, buf.Bytes(), 0644)
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	},
+}
+
+func isEndpoint(name string) bool {
+	return strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://")
+}
+
+// fetchSchema runs the introspection query against the endpoint sending the headers given by the --header flag.
+func fetchSchema(endpoint string) (*introspection.Schema, error) {
+	headers, err := schemaCmd.PersistentFlags().GetStringArray("header")
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	for _, h := range headers {
+		kv := strings.SplitN(h, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid header '%s': expected 'Name: value'", h)
+		}
+		header.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	return introspection.Fetch(client, endpoint, header)
+}
+
+func init() {
+	fetchCmd.Flags().String("endpoint", "", "URL of the GraphQL endpoint")
+	fetchCmd.Flags().String("to", "sdl", "output format (sdl, introspection)")
+	fetchCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+
+	schemaCmd.AddCommand(fetchCmd)
+}
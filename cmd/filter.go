@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/filter"
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/spf13/cobra"
+)
+
+var filterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Derive a subset of a schema selected by directives or tags",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in := cmd.Flag("in").Value.String()
+		doc, err := loadSchema(args[0], in)
+		if err != nil {
+			return err
+		}
+
+		var opts filter.Options
+		opts.ExcludeDirectives, _ = cmd.Flags().GetStringSlice("exclude-directive")
+		opts.IncludeTags, _ = cmd.Flags().GetStringSlice("include-tag")
+		doc = filter.Document(doc, opts)
+
+		var buf bytes.Buffer
+		if err := format.Document(&buf, doc, format.Options{}); err != nil {
+			return err
+		}
+		out := cmd.Flag("output").Value.String()
+		if out != "" {
+			if err := ioutil.WriteFile(out, buf.Bytes(), 0644); err != nil {
+				return err
+			}
+		}
+
+		// compare the published schema with the derived one instead of printing it
+		if published := cmd.Flag("compare").Value.String(); published != "" {
+			old, err := loadSchema(published, in)
+			if err != nil {
+				return err
+			}
+			res, err := compare.Documents(old, doc)
+			if err != nil {
+				return err
+			}
+			return printChanges(res)
+		}
+
+		if out == "" {
+			_, err = os.Stdout.Write(buf.Bytes())
+		}
+		return err
+	},
+}
+
+func init() {
+	filterCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	filterCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+	filterCmd.Flags().StringSlice("exclude-directive", nil, "remove items marked by the directive, e.g. @internal")
+	filterCmd.Flags().StringSlice("include-tag", nil, "keep only items tagged by @tag(name:) with the tag, e.g. public")
+	filterCmd.Flags().String("compare", "", "compare the given published schema with the derived one and print the changes")
+
+	schemaCmd.AddCommand(filterCmd)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/spf13/cobra"
+)
+
+var formatCmd = &cobra.Command{
+	Use:   "format",
+	Short: "Print schemas in the canonical form",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		check, _ := cmd.Flags().GetBool("check")
+		write, _ := cmd.Flags().GetBool("write")
+		sort, _ := cmd.Flags().GetBool("sort")
+		opts := format.Options{Sort: sort}
+		if check && write {
+			return fmt.Errorf("check is not supported with write")
+		}
+		silence(cmd)
+
+		unformatted := 0
+		for _, name := range args {
+			b, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			formatted, err := format.Schema(name, bytes.NewReader(b), opts)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case check:
+				if !bytes.Equal(b, formatted) {
+					fmt.Println(name)
+					unformatted++
+				}
+			case write:
+				if bytes.Equal(b, formatted) {
+					continue
+				}
+				if err := ioutil.WriteFile(name, formatted, 0644); err != nil {
+					return err
+				}
+			default:
+				os.Stdout.Write(formatted)
+			}
+		}
+
+		if unformatted > 0 {
+			return fmt.Errorf("%d schema(s) not formatted", unformatted)
+		}
+		return nil
+	},
+}
+
+func init() {
+	formatCmd.Flags().Bool("check", false, "list schemas which are not formatted and fail if there are any")
+	formatCmd.Flags().BoolP("write", "w", false, "write the result to the source file instead of stdout")
+	formatCmd.Flags().Bool("sort", false, "sort directives, types, fields and arguments alphabetically")
+
+	schemaCmd.AddCommand(formatCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/proto"
+	"github.com/spf13/cobra"
+)
+
+var fromProtoCmd = &cobra.Command{
+	Use:   "from-proto",
+	Short: "Generate a GraphQL schema from proto3 files",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, _ := cmd.Flags().GetStringSlice("proto-path")
+		files, deps, err := proto.Load(args, paths)
+		if err != nil {
+			return err
+		}
+
+		opts := proto.Options{
+			FieldNames: cmd.Flag("field-names").Value.String(),
+			TypePrefix: cmd.Flag("type-prefix").Value.String(),
+		}
+		opts.TrimEnumPrefix, _ = cmd.Flags().GetBool("trim-enum-prefix")
+		opts.Wrappers, _ = cmd.Flags().GetBool("wrappers")
+		if cmd.Flags().Changed("query-prefix") {
+			opts.QueryPrefixes, _ = cmd.Flags().GetStringSlice("query-prefix")
+		}
+		doc, err := proto.Convert(files, deps, opts)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := format.Document(&buf, doc, format.Options{}); err != nil {
+			return err
+		}
+		return writeOutput(cmd, buf.Bytes())
+	},
+}
+
+func init() {
+	fromProtoCmd.Flags().StringSliceP("proto-path", "I", nil, "directory to look up imports in, can be repeated")
+	fromProtoCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+	fromProtoCmd.Flags().String("field-names", "camel", "naming of fields and arguments (camel, proto)")
+	fromProtoCmd.Flags().String("type-prefix", "", "prefix of the names of the generated types")
+	fromProtoCmd.Flags().Bool("trim-enum-prefix", false, "remove the enum name from its values, e.g. STATUS_ACTIVE becomes ACTIVE")
+	fromProtoCmd.Flags().Bool("wrappers", false, "keep wrapper types such as google.protobuf.StringValue as object types instead of nullable scalars")
+	fromProtoCmd.Flags().StringSlice("query-prefix", nil, "prefix of the methods turned into Query fields, can be repeated (default Get, List, Search, Find, Lookup, Count, BatchGet)")
+
+	schemaCmd.AddCommand(fromProtoCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/graph"
+	"github.com/spf13/cobra"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Render types and their relationships as a DOT or Mermaid graph",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in := cmd.Flag("in").Value.String()
+		doc, err := loadSchema(args[0], in)
+		if err != nil {
+			return err
+		}
+
+		var opts graph.Options
+		opts.Format = graph.Format(cmd.Flag("format").Value.String())
+		opts.Root = cmd.Flag("root").Value.String()
+		opts.Depth, _ = cmd.Flags().GetInt("depth")
+
+		// highlight the types changed since the given schema
+		if old := cmd.Flag("compare").Value.String(); old != "" {
+			sx, err := loadSchema(old, in)
+			if err != nil {
+				return err
+			}
+			res, err := compare.Documents(sx, doc)
+			if err != nil {
+				return err
+			}
+			opts.Highlight = graph.Changed(res)
+		}
+
+		var buf bytes.Buffer
+		if err := graph.Write(&buf, doc, opts); err != nil {
+			return err
+		}
+		if out := cmd.Flag("output").Value.String(); out != "" {
+			return ioutil.WriteFile(out, buf.Bytes(), 0644)
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	graphCmd.Flags().StringP("output", "o", "", "write the graph to a file instead of standard output")
+	graphCmd.Flags().StringP("format", "f", "dot", "graph format (dot, mermaid)")
+	graphCmd.Flags().String("root", "", "render only types reachable from the type")
+	graphCmd.Flags().Int("depth", 0, "render only types within the number of edges from the root type or root operation types")
+	graphCmd.Flags().String("compare", "", "highlight types changed since the given schema")
+
+	schemaCmd.AddCommand(graphCmd)
+}
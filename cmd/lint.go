@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mije/graphql-tools/pkg/schema/lint"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check schemas against the style rules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if list, _ := cmd.Flags().GetBool("list-rules"); list {
+			return printLintRules()
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires at least 1 arg(s), only received 0")
+		}
+
+		out := cmd.Flag("out").Value.String()
+		if out != "txt" && out != "json" {
+			return fmt.Errorf("unsupported output format")
+		}
+		silence(cmd)
+
+		cfg, err := loadLintConfig(cmd.Flag("config").Value.String())
+		if err != nil {
+			return err
+		}
+		presets, _ := cmd.Flags().GetStringSlice("preset")
+		cfg.Presets = append(cfg.Presets, presets...)
+
+		diagnostics := []lint.Diagnostic{}
+		for _, name := range args {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			d, err := lint.Schema(name, f, cfg)
+			f.Close()
+			if err != nil {
+				return err
+			}
+			diagnostics = append(diagnostics, d...)
+		}
+
+		if out == "json" {
+			if err := json.NewEncoder(os.Stdout).Encode(diagnostics); err != nil {
+				return err
+			}
+		} else {
+			for _, d := range diagnostics {
+				fmt.Println(d)
+			}
+		}
+
+		if lint.HasErrors(diagnostics) {
+			return fmt.Errorf("schema does not conform to the lint rules")
+		}
+		return nil
+	},
+}
+
+func printLintRules() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "RULE\tSEVERITY\tDESCRIPTION\t")
+	for _, r := range lint.Rules() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", r.Name(), r.Severity(), r.Description())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nPresets: %s\n", strings.Join(lint.Presets(), ", "))
+	return nil
+}
+
+func loadLintConfig(name string) (lint.Config, error) {
+	if name == "" {
+		return lint.Config{}, nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return lint.Config{}, err
+	}
+	defer f.Close()
+	return lint.LoadConfig(f)
+}
+
+func init() {
+	lintCmd.Flags().StringP("config", "c", "", "JSON file overriding severity of the rules")
+	lintCmd.Flags().Bool("list-rules", false, "list available rules and their default severity")
+	lintCmd.Flags().StringP("out", "o", "txt", "output format (txt, json)")
+	lintCmd.Flags().StringSlice("preset", nil, "enable a preset of rules, e.g. relay")
+
+	schemaCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/ast"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge schema modules into a single schema",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silence(cmd)
+		in := cmd.Flag("in").Value.String()
+		sort, _ := cmd.Flags().GetBool("sort")
+
+		var docs []*ast.SchemaDocument
+		for _, name := range args {
+			doc, err := loadSchema(name, in)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+
+		doc, conflicts := merge.Documents(docs...)
+		if len(conflicts) > 0 {
+			for _, c := range conflicts {
+				fmt.Fprintln(os.Stderr, c)
+			}
+			return fmt.Errorf("%d conflict(s) found", len(conflicts))
+		}
+
+		var buf bytes.Buffer
+		if err := format.Document(&buf, doc, format.Options{Sort: sort}); err != nil {
+			return err
+		}
+		if out := cmd.Flag("output").Value.String(); out != "" {
+			return ioutil.WriteFile(out, buf.Bytes(), 0644)
+		}
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	},
+}
+
+var merge3Cmd = &cobra.Command{
+	Use:   "merge3",
+	Short: "Merge changes of two schemas made to their common base",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silence(cmd)
+		in := cmd.Flag("in").Value.String()
+
+		var docs []*ast.SchemaDocument
+		for _, name := range args {
+			doc, err := loadSchema(name, in)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+
+		doc, conflicts, err := merge.ThreeWay(docs[0], docs[1], docs[2])
+		if err != nil {
+			return err
+		}
+		if doc != nil {
+			var buf bytes.Buffer
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				return err
+			}
+			if out := cmd.Flag("output").Value.String(); out != "" {
+				err = ioutil.WriteFile(out, buf.Bytes(), 0644)
+			} else {
+				_, err = os.Stdout.Write(buf.Bytes())
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(conflicts) > 0 {
+			for _, c := range conflicts {
+				fmt.Fprintln(os.Stderr, c)
+			}
+			return fmt.Errorf("%d conflict(s) found", len(conflicts))
+		}
+		return nil
+	},
+}
+
+func init() {
+	mergeCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	mergeCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+	mergeCmd.Flags().Bool("sort", false, "sort directives, types, fields and arguments alphabetically")
+
+	merge3Cmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	merge3Cmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+
+	schemaCmd.AddCommand(mergeCmd)
+	schemaCmd.AddCommand(merge3Cmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/mock"
+	"github.com/spf13/cobra"
+)
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Serve a GraphQL endpoint of a schema resolving to generated data",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+		cfg, err := loadMockConfig(cmd.Flag("config").Value.String())
+		if err != nil {
+			return err
+		}
+		server, err := mock.New(doc, cfg)
+		if err != nil {
+			return err
+		}
+
+		port, _ := cmd.Flags().GetInt("port")
+		fmt.Printf("Serving mock GraphQL endpoint at http://localhost:%d/graphql\n", port)
+		return http.ListenAndServe(fmt.Sprintf(":%d", port), server)
+	},
+}
+
+func loadMockConfig(name string) (mock.Config, error) {
+	if name == "" {
+		return mock.Config{}, nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return mock.Config{}, err
+	}
+	defer f.Close()
+	return mock.LoadConfig(f)
+}
+
+func init() {
+	mockCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	mockCmd.Flags().StringP("config", "c", "", "JSON file configuring values of the scalars and length of the lists")
+	mockCmd.Flags().Int("port", 4000, "port to listen on")
+
+	schemaCmd.AddCommand(mockCmd)
+}
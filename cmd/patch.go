@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/patch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patchCmd = &cobra.Command{
+		Use:   "patch",
+		Short: "Replay changes between schemas",
+	}
+
+	patchApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a patch created by 'schema compare --out patch' to a schema",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(args[1])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			p, err := patch.Read(f)
+			if err != nil {
+				return err
+			}
+
+			doc, err = patch.Apply(doc, p)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				return err
+			}
+			if out := cmd.Flag("output").Value.String(); out != "" {
+				return ioutil.WriteFile(out, buf.Bytes(), 0644)
+			}
+			_, err = os.Stdout.Write(buf.Bytes())
+			return err
+		},
+	}
+)
+
+func printPatch(x, y, format string) error {
+	sx, err := loadSchema(x, format)
+	if err != nil {
+		return err
+	}
+	sy, err := loadSchema(y, format)
+	if err != nil {
+		return err
+	}
+
+	p, err := patch.Create(sx, sy)
+	if err != nil {
+		return err
+	}
+	e := json.NewEncoder(os.Stdout)
+	e.SetIndent("", "  ")
+	return e.Encode(p)
+}
+
+func init() {
+	patchApplyCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	patchApplyCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+
+	patchCmd.AddCommand(patchApplyCmd)
+	schemaCmd.AddCommand(patchCmd)
+}
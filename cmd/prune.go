@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/prune"
+	"github.com/spf13/cobra"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove types unreachable from the root types",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silence(cmd)
+		doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			names := prune.Unreachable(doc)
+			for _, name := range names {
+				fmt.Println(name)
+			}
+			if len(names) > 0 {
+				return fmt.Errorf("%d unreachable type(s) found", len(names))
+			}
+			return nil
+		}
+
+		doc, names := prune.Document(doc)
+		for _, name := range names {
+			fmt.Fprintf(os.Stderr, "Type '%s' was removed\n", name)
+		}
+
+		var buf bytes.Buffer
+		if err := format.Document(&buf, doc, format.Options{}); err != nil {
+			return err
+		}
+		if out := cmd.Flag("output").Value.String(); out != "" {
+			return ioutil.WriteFile(out, buf.Bytes(), 0644)
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	},
+}
+
+func init() {
+	pruneCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	pruneCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+	pruneCmd.Flags().Bool("check", false, "list unreachable types instead of removing them and fail if there are any")
+
+	schemaCmd.AddCommand(pruneCmd)
+}
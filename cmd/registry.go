@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mije/graphql-tools/pkg/schema/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "Manage schema versions in a local registry",
+	}
+	registryPublishCmd = &cobra.Command{
+		Use:   "publish",
+		Short: "Publish a new schema version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, service, env, err := openRegistry(cmd)
+			if err != nil {
+				return err
+			}
+			silence(cmd)
+
+			sdl, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			ack, _ := cmd.Flags().GetBool("allow-breaking")
+			e, res, err := r.Publish(service, env, sdl, ack)
+			if err == registry.ErrUnchanged {
+				fmt.Printf("Schema unchanged, latest is %s/%s version %d\n", e.Service, e.Environment, e.Version)
+				return nil
+			}
+			if err == registry.ErrBreakingChanges {
+				if err := printChanges(res); err != nil {
+					return err
+				}
+				return fmt.Errorf("%v, use --allow-breaking to publish anyway", err)
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Published %s/%s version %d\n", e.Service, e.Environment, e.Version)
+			return nil
+		},
+	}
+	registryCheckCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Compare a schema against the latest published version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, service, env, err := openRegistry(cmd)
+			if err != nil {
+				return err
+			}
+			silence(cmd)
+
+			sdl, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := r.Check(service, env, sdl)
+			if err != nil {
+				return err
+			}
+			if err := printChanges(res); err != nil {
+				return err
+			}
+			if n := len(res.Breaking()); n > 0 {
+				return fmt.Errorf("%d breaking change(s) detected", n)
+			}
+			return nil
+		},
+	}
+	registryHistoryCmd = &cobra.Command{
+		Use:   "history",
+		Short: "List published schema versions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, service, env, err := openRegistry(cmd)
+			if err != nil {
+				return err
+			}
+
+			entries, err := r.History(service, env)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tPUBLISHED\tBREAKING\tDIGEST\t")
+			for _, e := range entries {
+				fmt.Fprintf(w, "%d\t%s\t%t\t%s\t\n", e.Version, e.Published.Format(time.RFC3339), e.Breaking, e.Digest)
+			}
+			return w.Flush()
+		},
+	}
+	registryFetchCmd = &cobra.Command{
+		Use:   "fetch [version]",
+		Short: "Print a published schema version, the latest one by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, service, env, err := openRegistry(cmd)
+			if err != nil {
+				return err
+			}
+
+			var version int
+			if len(args) > 0 {
+				if version, err = strconv.Atoi(args[0]); err != nil || version < 1 {
+					return fmt.Errorf("invalid version '%s'", args[0])
+				}
+			}
+
+			_, sdl, err := r.Fetch(service, env, version)
+			if err != nil {
+				return err
+			}
+
+			if out := cmd.Flag("output").Value.String(); out != "" {
+				return ioutil.WriteFile(out, sdl, 0644)
+			}
+			_, err = os.Stdout.Write(sdl)
+			return err
+		},
+	}
+)
+
+func openRegistry(cmd *cobra.Command) (*registry.Registry, string, string, error) {
+	service := cmd.Flag("service").Value.String()
+	if service == "" {
+		return nil, "", "", fmt.Errorf("service must be specified")
+	}
+	env := cmd.Flag("env").Value.String()
+
+	r, err := registry.Open(cmd.Flag("dir").Value.String())
+	if err != nil {
+		return nil, "", "", err
+	}
+	return r, service, env, nil
+}
+
+func init() {
+	registryCmd.PersistentFlags().String("dir", ".schema-registry", "registry directory")
+	registryCmd.PersistentFlags().StringP("service", "s", "", "service name")
+	registryCmd.PersistentFlags().StringP("env", "e", "default", "environment name")
+
+	registryPublishCmd.Flags().Bool("allow-breaking", false, "acknowledge breaking changes and publish anyway")
+	registryFetchCmd.Flags().StringP("output", "o", "", "write the schema to a file instead of standard output")
+
+	registryCmd.AddCommand(registryPublishCmd, registryCheckCmd, registryHistoryCmd, registryFetchCmd)
+	schemaCmd.AddCommand(registryCmd)
+}
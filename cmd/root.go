@@ -19,12 +19,21 @@ var (
 )
 
 func init() {
+	schemaCmd.PersistentFlags().StringArrayP("header", "H", nil, "HTTP header sent when fetching schemas from endpoints, e.g. 'Authorization: Bearer ...'")
+
 	rootCmd.AddCommand(schemaCmd)
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
+
+// silence stops cobra from printing the usage and the error, it is called once the flags and arguments are
+// checked since errors from there on are results of the command rather than its misuse. Execute prints the error.
+func silence(cmd *cobra.Command) {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+}
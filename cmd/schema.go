@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
 	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
 )
 
 var compareCmd = &cobra.Command{
@@ -14,42 +21,163 @@ var compareCmd = &cobra.Command{
 	Short: "Compare two schemas",
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if cmd.Flag("in").Value.String() != "sdl" {
-			return fmt.Errorf("unsupported input format")
-		}
-		if cmd.Flag("out").Value.String() != "txt" {
+		in, out := cmd.Flag("in").Value.String(), cmd.Flag("out").Value.String()
+		if out != "txt" && out != "json" && out != "patch" {
 			return fmt.Errorf("unsupported output format")
 		}
 
-		x, err := os.Open(args[0])
+		semver := cmd.Flag("semver").Value.String()
+		if semver != "" && out == "patch" {
+			return fmt.Errorf("semver is not supported with patch output")
+		}
+		watch, _ := cmd.Flags().GetBool("watch")
+		if watch && out == "patch" {
+			return fmt.Errorf("patch output is not supported in watch mode")
+		}
+		if watch && semver != "" {
+			return fmt.Errorf("semver is not supported in watch mode")
+		}
+		silence(cmd)
+
+		if v, _ := cmd.Flags().GetBool("validate"); v {
+			for _, name := range args {
+				errs, err := validateSchema(name, in)
+				if err != nil {
+					return err
+				}
+				if len(errs) > 0 {
+					for _, e := range errs {
+						fmt.Println(e)
+					}
+					return fmt.Errorf("invalid schema '%s'", name)
+				}
+			}
+		}
+
+		if watch {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			return watchSchemas(args, in, out, interval)
+		}
+
+		if out == "patch" {
+			return printPatch(args[0], args[1], in)
+		}
+
+		res, err := compareSchemas(args[0], args[1], in)
 		if err != nil {
 			return err
 		}
-		defer x.Close()
 
-		y, err := os.Open(args[1])
+		if semver != "" {
+			return printVersion(cmd, res, semver)
+		}
+
+		if out == "json" {
+			return json.NewEncoder(os.Stdout).Encode(res)
+		}
+		return printChanges(res)
+	},
+}
+
+func compareSchemas(x, y, format string) (*compare.Result, error) {
+	sx, err := loadSchema(x, format)
+	if err != nil {
+		return nil, err
+	}
+	sy, err := loadSchema(y, format)
+	if err != nil {
+		return nil, err
+	}
+	return compare.Documents(sx, sy)
+}
+
+// loadSchema reads a schema file encoded either using SDL or as an introspection query result.
+// HTTP(S) URLs are treated as GraphQL endpoints, the schema is fetched using the introspection query then.
+func loadSchema(name, format string) (*ast.SchemaDocument, error) {
+	if isEndpoint(name) {
+		s, err := fetchSchema(name)
+		if err != nil {
+			return nil, err
+		}
+		return s.Document()
+	}
+
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "sdl":
+		doc, err := parser.ParseSchema(&ast.Source{Name: name, Input: string(b)})
 		if err != nil {
-			return err
+			return nil, err
+		}
+		return doc, nil
+	case "introspection":
+		s, err := introspection.Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
 		}
-		defer y.Close()
+		return s.Document()
+	default:
+		return nil, fmt.Errorf("unsupported input format")
+	}
+}
+
+func printChanges(res *compare.Result) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PATH\tSEVERITY\tTYPE\tDESCRIPTION\t")
+	for _, c := range res.Changes() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", c.Path, c.Severity.Level, c.Type, c.Message)
+	}
+	return w.Flush()
+}
+
+func printVersion(cmd *cobra.Command, res *compare.Result, current string) error {
+	v, err := compare.ParseVersion(current)
+	if err != nil {
+		return err
+	}
 
-		res, err := compare.Schema(x, y)
+	rules := compare.DefaultVersionRules()
+	overrides, err := cmd.Flags().GetStringSlice("semver-rule")
+	if err != nil {
+		return err
+	}
+	for _, o := range overrides {
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid semver rule '%s': expected KEY=INCREMENT", o)
+		}
+		inc, err := compare.ParseVersionIncrement(kv[1])
 		if err != nil {
 			return err
 		}
-
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "PATH\tSEVERITY\tTYPE\tDESCRIPTION\t")
-		for _, c := range res.Changes() {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", c.Path, c.Severity.Level, c.Type, c.Message)
+		switch l := compare.ChangeSeverityLevel(kv[0]); l {
+		case compare.Breaking, compare.Dangerous, compare.NonBreaking:
+			rules.Levels[l] = inc
+		default:
+			t, err := compare.ParseChangeType(kv[0])
+			if err != nil {
+				return fmt.Errorf("invalid semver rule '%s': %v", o, err)
+			}
+			rules.Types[t] = inc
 		}
-		return w.Flush()
-	},
+	}
+
+	fmt.Println(res.RecommendVersion(v, rules))
+	return nil
 }
 
 func init() {
-	compareCmd.Flags().StringP("in", "i", "sdl", "input format")
-	compareCmd.Flags().StringP("out", "o", "txt", "output format")
+	compareCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	compareCmd.Flags().StringP("out", "o", "txt", "output format (txt, json, patch)")
+	compareCmd.Flags().Bool("validate", false, "validate both schemas before comparing them")
+	compareCmd.Flags().BoolP("watch", "w", false, "re-run the comparison whenever the schemas change and print the changes since the previous run")
+	compareCmd.Flags().Duration("interval", time.Second, "interval of checking the schemas for modifications in watch mode")
+	compareCmd.Flags().String("semver", "", "print the version following the given one instead of the changes")
+	compareCmd.Flags().StringSlice("semver-rule", nil, "override version increment of a severity level or change type, e.g. DANGEROUS=patch")
 
 	schemaCmd.AddCommand(compareCmd)
 }
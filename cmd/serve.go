@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mije/graphql-tools/pkg/server"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the tools over HTTP",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr := cmd.Flag("addr").Value.String()
+		fmt.Printf("Listening on %s\n", addr)
+		return http.ListenAndServe(addr, server.NewHandler())
+	},
+}
+
+func init() {
+	serveCmd.Flags().String("addr", ":8080", "address to listen on")
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mije/graphql-tools/pkg/schema/stats"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/ast"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report size, complexity and documentation coverage of a schema",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.Flag("out").Value.String()
+		if out != "txt" && out != "json" {
+			return fmt.Errorf("unsupported output format")
+		}
+
+		doc, err := loadSchema(args[0], cmd.Flag("in").Value.String())
+		if err != nil {
+			return err
+		}
+
+		s := stats.Document(doc)
+		if out == "json" {
+			return json.NewEncoder(os.Stdout).Encode(s)
+		}
+		return printStats(s)
+	},
+}
+
+var statsKinds = []struct {
+	kind  ast.DefinitionKind
+	label string
+}{
+	{ast.Object, "Objects"},
+	{ast.Interface, "Interfaces"},
+	{ast.Union, "Unions"},
+	{ast.Enum, "Enums"},
+	{ast.InputObject, "Input objects"},
+	{ast.Scalar, "Scalars"},
+}
+
+func printStats(s *stats.Stats) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	for _, k := range statsKinds {
+		fmt.Fprintf(w, "%s\t%d\t\n", k.label, s.Types[k.kind])
+	}
+	fmt.Fprintf(w, "Directives\t%d\t\n", s.Directives)
+	fmt.Fprintf(w, "Fields\t%d\t\n", s.Fields)
+	fmt.Fprintf(w, "Arguments\t%d\t\n", s.Arguments)
+	fmt.Fprintf(w, "Enum values\t%d\t\n", s.EnumValues)
+	fmt.Fprintf(w, "Deprecated\t%d\t\n", s.Deprecated)
+	fmt.Fprintf(w, "Max depth\t%d\t\n", s.MaxDepth)
+	fmt.Fprintf(w, "Descriptions\t%d/%d (%.1f%%)\t\n", s.Descriptions.Described, s.Descriptions.Total, s.Descriptions.Percent())
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(s.FanOut))
+	for name := range s.FanOut {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if s.FanOut[names[i]] != s.FanOut[names[j]] {
+			return s.FanOut[names[i]] > s.FanOut[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	fmt.Println("\nFan-out:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%d\t\n", name, s.FanOut[name])
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(s.Cycles) > 0 {
+		fmt.Println("\nCycles:")
+		for _, c := range s.Cycles {
+			fmt.Printf("  %s\n", strings.Join(c, ", "))
+		}
+	}
+	return nil
+}
+
+func init() {
+	statsCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+	statsCmd.Flags().StringP("out", "o", "txt", "output format (txt, json)")
+
+	schemaCmd.AddCommand(statsCmd)
+}
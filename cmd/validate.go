@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mije/graphql-tools/pkg/schema/validate"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate schemas",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		silence(cmd)
+		in := cmd.Flag("in").Value.String()
+
+		invalid := 0
+		for _, name := range args {
+			errs, err := validateSchema(name, in)
+			if err != nil {
+				return err
+			}
+			for _, e := range errs {
+				fmt.Println(e)
+			}
+			if len(errs) > 0 {
+				invalid++
+			}
+		}
+
+		if invalid > 0 {
+			return fmt.Errorf("%d invalid schema(s)", invalid)
+		}
+		return nil
+	},
+}
+
+func validateSchema(name, format string) ([]validate.Error, error) {
+	if format == "sdl" && !isEndpoint(name) {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return validate.Schema(name, f)
+	}
+
+	doc, err := loadSchema(name, format)
+	if err != nil {
+		return nil, err
+	}
+	return validate.Document(doc), nil
+}
+
+func init() {
+	validateCmd.Flags().StringP("in", "i", "sdl", "input format (sdl, introspection)")
+
+	schemaCmd.AddCommand(validateCmd)
+}
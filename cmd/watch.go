@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+)
+
+// watchSchemas compares the schemas whenever any of them is modified and prints the changes
+// which appeared or disappeared since the previous comparison.
+func watchSchemas(files []string, in, out string, interval time.Duration) error {
+	var (
+		prev    []compare.Change
+		modTime = make(map[string]time.Time)
+	)
+
+	logError := func(err error) {
+		fmt.Fprintf(os.Stderr, "%s %v\n", time.Now().Format("15:04:05"), err)
+	}
+
+poll:
+	for ; ; time.Sleep(interval) {
+		modified := false
+		for _, f := range files {
+			if isEndpoint(f) { // endpoints are fetched on every tick, only the changes in between are printed
+				modified = true
+				continue
+			}
+			fi, err := os.Stat(f)
+			if err != nil { // editors may replace the file while saving it
+				logError(err)
+				continue poll
+			}
+			if t := fi.ModTime(); !t.Equal(modTime[f]) {
+				modTime[f] = t
+				modified = true
+			}
+		}
+		if !modified {
+			continue
+		}
+
+		res, err := compareSchemas(files[0], files[1], in)
+		if err != nil {
+			logError(err)
+			continue
+		}
+
+		next := res.Changes()
+		added, removed := diffChanges(prev, next)
+		prev = next
+
+		if err := printDelta(out, added, removed); err != nil {
+			return err
+		}
+	}
+}
+
+func diffChanges(prev, next []compare.Change) (added, removed []compare.Change) {
+	inPrev := make(map[compare.Change]bool)
+	for _, c := range prev {
+		inPrev[c] = true
+	}
+	inNext := make(map[compare.Change]bool)
+	for _, c := range next {
+		inNext[c] = true
+		if !inPrev[c] {
+			added = append(added, c)
+		}
+	}
+	for _, c := range prev {
+		if !inNext[c] {
+			removed = append(removed, c)
+		}
+	}
+	return added, removed
+}
+
+func printDelta(out string, added, removed []compare.Change) error {
+	if out == "json" {
+		nonNil := func(changes []compare.Change) []compare.Change {
+			if changes == nil {
+				return []compare.Change{}
+			}
+			return changes
+		}
+		return json.NewEncoder(os.Stdout).Encode(struct {
+			Added   []compare.Change `json:"added"`
+			Removed []compare.Change `json:"removed"`
+		}{nonNil(added), nonNil(removed)})
+	}
+
+	fmt.Printf("%s %d change(s) added, %d change(s) removed\n", time.Now().Format("15:04:05"), len(added), len(removed))
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "\tPATH\tSEVERITY\tTYPE\tDESCRIPTION\t")
+	for _, c := range added {
+		fmt.Fprintf(w, "+\t%s\t%s\t%s\t%s\t\n", c.Path, c.Severity.Level, c.Type, c.Message)
+	}
+	for _, c := range removed {
+		fmt.Fprintf(w, "-\t%s\t%s\t%s\t%s\t\n", c.Path, c.Severity.Level, c.Type, c.Message)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,133 @@
+package codegen
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+var builtInScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// roots returns the names of the root operation types, the default ones unless the schema definition says otherwise.
+func roots(doc *ast.SchemaDocument) map[string]bool {
+	names := make(map[string]bool)
+	for _, list := range [][]*ast.SchemaDefinition{doc.Schema, doc.SchemaExtension} {
+		for _, def := range list {
+			for _, op := range def.OperationTypes {
+				names[op.Type] = true
+			}
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+	for _, name := range []string{"Query", "Mutation", "Subscription"} {
+		names[name] = true
+	}
+	return names
+}
+
+var initialisms = map[string]bool{
+	"API":   true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"JSON":  true,
+	"SQL":   true,
+	"UID":   true,
+	"URI":   true,
+	"URL":   true,
+	"UUID":  true,
+	"XML":   true,
+}
+
+// words splits the name at underscores, hyphens and case changes, e.g. HTTPServer_url into HTTP, Server and url.
+func words(name string) []string {
+	var res []string
+	var word []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(word) > 0 {
+			res = append(res, string(word))
+			word = nil
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return res
+}
+
+// pascal joins the words of the name capitalized, initialisms upper cased, e.g. user_ids becomes UserIDs.
+func pascal(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		u := strings.ToUpper(w)
+		if initialisms[u] {
+			b.WriteString(u)
+			continue
+		}
+		if strings.HasSuffix(w, "s") && initialisms[u[:len(u)-1]] {
+			b.WriteString(u[:len(u)-1] + "s")
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+	return b.String()
+}
+
+// comment returns the description and deprecation of an element as lines of a comment with the prefix.
+func comment(prefix, description string, directives ast.DirectiveList) string {
+	lines := descriptionLines(description)
+	if reason, ok := deprecation(directives); ok {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Deprecated: "+reason)
+	}
+
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(strings.TrimRight(prefix+" "+l, " ") + "\n")
+	}
+	return b.String()
+}
+
+func descriptionLines(description string) []string {
+	if description == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(description), "\n")
+}
+
+func deprecation(directives ast.DirectiveList) (string, bool) {
+	d := directives.ForName("deprecated")
+	if d == nil {
+		return "", false
+	}
+	if r := d.Arguments.ForName("reason"); r != nil && r.Value != nil {
+		return r.Value.Raw, true
+	}
+	return "No longer supported", true
+}
@@ -0,0 +1,507 @@
+package codegen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const schemaSDL = `
+scalar DateTime
+scalar UUID
+
+enum Role {
+  ADMIN
+  "A guest"
+  GUEST_USER @deprecated(reason: "Use ADMIN")
+}
+
+interface Node {
+  id: ID!
+}
+
+"A user of the service"
+type User implements Node {
+  id: ID!
+  name: String!
+  role: Role
+  created: DateTime
+  uid: UUID
+  manager: User!
+  friends: [User!]!
+  tags: [String]
+}
+
+union Item = User
+
+input UserInput {
+  name: String!
+  ids: [ID!]
+}
+
+type Query {
+  me: User
+  items: [Item]
+  user(id: ID!, in: UserInput): User
+}
+`
+
+func parseSchema(t *testing.T) *ast.SchemaDocument {
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaSDL})
+	if gqlErr != nil {
+		t.Fatal(gqlErr)
+	}
+	return doc
+}
+
+func TestPascal(t *testing.T) {
+	testData := []struct {
+		name string
+		want string
+	}{
+		{name: "user", want: "User"},
+		{name: "userId", want: "UserID"},
+		{name: "user_ids", want: "UserIDs"},
+		{name: "GUEST_USER", want: "GuestUser"},
+		{name: "HTTPServer", want: "HTTPServer"},
+		{name: "avatarUrl", want: "AvatarURL"},
+		{name: "address2", want: "Address2"},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pascal(tt.name); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGo(t *testing.T) {
+	testData := []struct {
+		name string
+		opts GoOptions
+		want []string
+	}{
+		{
+			name: "package",
+			want: []string{"package model\n"},
+		},
+		{
+			name: "enum",
+			want: []string{
+				"type Role string\n",
+				"\tRoleAdmin Role = \"ADMIN\"\n\t// A guest\n\t//\n\t// Deprecated: Use ADMIN\n\tRoleGuestUser Role = \"GUEST_USER\"\n",
+				"var AllRole = []Role{RoleAdmin, RoleGuestUser}\n",
+				"func (e *Role) UnmarshalJSON(b []byte) error {",
+				"return fmt.Errorf(\"invalid Role value '%s'\", s)",
+			},
+		},
+		{
+			name: "struct",
+			want: []string{
+				"// A user of the service\ntype User struct {\n",
+				"\tID      string    `json:\"id\"`\n",
+				"\tRole    *Role     `json:\"role\"`\n",
+				"\tCreated *DateTime `json:\"created\"`\n",
+				"\tManager *User     `json:\"manager\"`\n",
+				"\tFriends []*User   `json:\"friends\"`\n",
+				"\tTags    []*string `json:\"tags\"`\n",
+			},
+		},
+		{
+			name: "input",
+			want: []string{
+				"\tName string   `json:\"name\"`\n",
+				"\tIDs  []string `json:\"ids,omitempty\"`\n",
+			},
+		},
+		{
+			name: "interfaces and unions",
+			want: []string{
+				"type Node interface {\n\tIsNode()\n}\n",
+				"type Item interface {\n\tIsItem()\n}\n",
+				"func (User) IsNode() {}\n",
+				"func (User) IsItem() {}\n",
+			},
+		},
+		{
+			name: "custom scalars",
+			want: []string{"type DateTime string\n", "type UUID string\n"},
+		},
+		{
+			name: "mapped scalars",
+			opts: GoOptions{
+				Package: "api",
+				Scalars: map[string]string{"DateTime": "time.Time", "UUID": "github.com/google/uuid.UUID"},
+			},
+			want: []string{
+				"package api\n",
+				"\t\"time\"\n\n\t\"github.com/google/uuid\"\n",
+				"\tCreated *time.Time `json:\"created\"`\n",
+				"\tUID     *uuid.UUID `json:\"uid\"`\n",
+			},
+		},
+	}
+
+	doc := parseSchema(t)
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Go(&buf, doc, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got %s, want it to contain %q", got, want)
+				}
+			}
+			if strings.Contains(got, "type Query") {
+				t.Errorf("got %s, want the root types left out", got)
+			}
+		})
+	}
+}
+
+const operationsSDL = `
+query GetMe($id: ID!, $in: UserInput) {
+  me {
+    __typename
+    uid: id
+    ...UserFields
+    friends @include(if: true) {
+      name
+    }
+  }
+  items {
+    ... on User {
+      __typename
+      name
+    }
+  }
+  user(id: $id, in: $in) {
+    id
+  }
+}
+
+fragment UserFields on User {
+  role
+  tags
+}
+`
+
+func TestTypeScript(t *testing.T) {
+	testData := []struct {
+		name string
+		opts TypeScriptOptions
+		want []string
+	}{
+		{
+			name: "scalars",
+			opts: TypeScriptOptions{Scalars: map[string]string{"DateTime": "string"}},
+			want: []string{
+				"export type DateTime = string;\n",
+				"export type UUID = any;\n",
+			},
+		},
+		{
+			name: "enum",
+			want: []string{"export type Role = \"ADMIN\" | \"GUEST_USER\";\n"},
+		},
+		{
+			name: "object",
+			want: []string{
+				"/**\n * A user of the service\n */\nexport type User = {\n  __typename?: \"User\";\n  id: string;\n",
+				"  role: Role | null;\n",
+				"  friends: Array<User>;\n",
+				"  tags: Array<string | null> | null;\n",
+			},
+		},
+		{
+			name: "interface, union and input",
+			want: []string{
+				"export type Node = {\n  id: string;\n};\n",
+				"export type Item = User;\n",
+				"export type UserInput = {\n  name: string;\n  ids?: Array<string> | null;\n};\n",
+			},
+		},
+		{
+			name: "fragment",
+			want: []string{"export type UserFieldsFragment = {\n  role: Role | null;\n  tags: Array<string | null> | null;\n};\n"},
+		},
+		{
+			name: "variables",
+			want: []string{"export type GetMeQueryVariables = {\n  id: string;\n  in?: UserInput | null;\n};\n"},
+		},
+		{
+			name: "result",
+			want: []string{
+				"export type GetMeQuery = {\n" +
+					"  me: {\n" +
+					"    __typename: \"User\";\n" +
+					"    uid: string;\n" +
+					"    role: Role | null;\n" +
+					"    tags: Array<string | null> | null;\n" +
+					"    friends?: Array<{\n" +
+					"      name: string;\n" +
+					"    }>;\n" +
+					"  } | null;\n" +
+					"  items: Array<{\n" +
+					"    __typename: \"User\";\n" +
+					"    name: string;\n" +
+					"  } | null> | null;\n" +
+					"  user: {\n" +
+					"    id: string;\n" +
+					"  } | null;\n" +
+					"};\n",
+			},
+		},
+	}
+
+	doc := parseSchema(t)
+	ops, err := LoadOperations(doc, &ast.Source{Name: "ops.graphql", Input: operationsSDL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := TypeScript(&buf, doc, ops, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got %s, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadOperationsErrors(t *testing.T) {
+	testData := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "syntax",
+			input: `query {`,
+			want:  `ops.graphql:1: Expected Name, found <EOF>`,
+		},
+		{
+			name:  "validation",
+			input: `query A { unknown }`,
+			want:  `ops.graphql:1: Cannot query field "unknown" on type "Query".`,
+		},
+		{
+			name:  "anonymous",
+			input: `{ me { id } }`,
+			want:  `ops.graphql:1: operations must be named`,
+		},
+	}
+
+	doc := parseSchema(t)
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadOperations(doc, &ast.Source{Name: "ops.graphql", Input: tt.input})
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoClient(t *testing.T) {
+	testData := []struct {
+		name string
+		opts GoClientOptions
+		want []string
+	}{
+		{
+			name: "transport",
+			want: []string{
+				"package client\n",
+				"func NewClient(endpoint string) *Client {",
+				"func (c *Client) Do(ctx context.Context, document, operationName string, variables, data interface{}) error {",
+			},
+		},
+		{
+			name: "document",
+			want: []string{
+				"const GetMeDocument = `query GetMe($id: ID!, $in: UserInput) {\n  me {\n    __typename\n    uid: id\n    ...UserFields\n" +
+					"    friends @include(if: true) {\n      name\n    }\n  }\n",
+				"\n}\n\nfragment UserFields on User {\n  role\n  tags\n}`\n",
+			},
+		},
+		{
+			name: "variables",
+			want: []string{"type GetMeVariables struct {\n\tID string     `json:\"id\"`\n\tIn *UserInput `json:\"in,omitempty\"`\n}\n"},
+		},
+		{
+			name: "function",
+			want: []string{
+				"func (c *Client) GetMe(ctx context.Context, vars GetMeVariables) (*GetMeResponse, error) {\n" +
+					"\tvar resp GetMeResponse\n" +
+					"\tif err := c.Do(ctx, GetMeDocument, \"GetMe\", vars, &resp); err != nil {\n",
+			},
+		},
+		{
+			name: "response",
+			want: []string{
+				"type GetMeResponse struct {\n\tMe    *GetMeResponseMe      `json:\"me\"`\n\tItems []*GetMeResponseItems `json:\"items\"`\n\tUser  *GetMeResponseUser    `json:\"user\"`\n}\n",
+				"type GetMeResponseMe struct {\n" +
+					"\tTypename string                   `json:\"__typename\"`\n" +
+					"\tUID      string                   `json:\"uid\"`\n" +
+					"\tRole     *Role                    `json:\"role\"`\n" +
+					"\tTags     []*string                `json:\"tags\"`\n" +
+					"\tFriends  []GetMeResponseMeFriends `json:\"friends\"`\n" +
+					"}\n",
+			},
+		},
+		{
+			name: "referenced types",
+			opts: GoClientOptions{Package: "api"},
+			want: []string{
+				"package api\n",
+				"type Role string\n",
+				"type UserInput struct {\n",
+			},
+		},
+	}
+
+	doc := parseSchema(t)
+	ops, err := LoadOperations(doc, &ast.Source{Name: "ops.graphql", Input: operationsSDL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := GoClient(&buf, doc, ops, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("got %s, want it to contain %q", got, want)
+				}
+			}
+			if strings.Contains(got, "type DateTime") {
+				t.Errorf("got %s, want the types not referred to left out", got)
+			}
+		})
+	}
+}
+
+func TestGoClientNames(t *testing.T) {
+	testData := []struct {
+		name  string
+		input string
+		want  string
+		err   string
+	}{
+		{
+			name:  "nested structs",
+			input: `query Q { me { friends { id } } meFriends: me { id } }`,
+			want:  "type QResponseMeFriends2 struct {",
+		},
+		{
+			name:  "fields",
+			input: `query Q { me { user_id: id userId: id } }`,
+			err:   "struct 'QResponseMe': field 'user_id' and field 'userId' are both named 'UserID' in Go",
+		},
+		{
+			name:  "variables",
+			input: `query Q($user_id: ID!, $userId: ID!) { a: user(id: $user_id) { id } b: user(id: $userId) { id } }`,
+			err:   "operation 'Q': variable 'user_id' and variable 'userId' are both named 'UserID' in Go",
+		},
+		{
+			name:  "transport",
+			input: `query Do { me { id } }`,
+			err:   "operation 'Do': name 'Do' is already declared",
+		},
+		{
+			name:  "operations",
+			input: `query Q { me { id } } query QResponse { me { id } }`,
+			err:   "operation 'QResponse': name 'QResponse' is already declared",
+		},
+	}
+
+	doc := parseSchema(t)
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := LoadOperations(doc, &ast.Source{Name: "ops.graphql", Input: tt.input})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			err = GoClient(&buf, doc, ops, GoClientOptions{})
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("got %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("got %s, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoNames(t *testing.T) {
+	testData := []struct {
+		name  string
+		input string
+		want  string
+		err   string
+	}{
+		{
+			name:  "placeholder field",
+			input: `type T { _: Boolean }`,
+			want:  "\tX_ *bool `json:\"_\"`\n",
+		},
+		{
+			name:  "fields",
+			input: `type T { user_id: ID userId: ID }`,
+			err:   "type 'T': field 'user_id' and field 'userId' are both named 'UserID' in Go",
+		},
+		{
+			name:  "marker methods",
+			input: `interface Node { id: ID } type T implements Node { id: ID isNode: Boolean }`,
+			err:   "type 'T': the marker method of 'Node' and field 'isNode' are both named 'IsNode' in Go",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, gqlErr := parser.ParseSchema(&ast.Source{Input: tt.input})
+			if gqlErr != nil {
+				t.Fatal(gqlErr)
+			}
+			var buf bytes.Buffer
+			err := Go(&buf, doc, GoOptions{})
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("got %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("got %s, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// GoClientOptions configures generation of Go clients.
+type GoClientOptions struct {
+
+	// Package is the name of the generated package, client unless set
+	Package string
+
+	// Scalars maps scalar types to Go types, see GoOptions
+	Scalars map[string]string
+}
+
+// GoClient writes a Go client of the operations loaded by LoadOperations: a method of the client per operation
+// taking the typed variables and returning the typed response, the structs of which have exactly the fields selected.
+// The input, enum and scalar types the operations refer to are generated as by Go. Fields selected on interfaces
+// and unions are merged into a single struct, those not selected on all possible types are optional.
+func GoClient(w io.Writer, doc *ast.SchemaDocument, ops *ast.QueryDocument, opts GoClientOptions) error {
+	if opts.Package == "" {
+		opts.Package = "client"
+	}
+	schema, err := introspection.LoadSchema(doc)
+	if err != nil {
+		return err
+	}
+	doc, _ = merge.Documents(doc)
+	g, err := newGoGen(doc, opts.Scalars)
+	if err != nil {
+		return err
+	}
+
+	c := &clientGen{
+		goGen:  g,
+		schema: schema,
+		ops:    ops,
+		used:   make(map[string]bool),
+		names:  make(map[string]bool),
+	}
+	for _, name := range goClientNames {
+		c.names[name] = true
+	}
+	for _, def := range doc.Definitions {
+		switch def.Kind {
+		case ast.InputObject, ast.Enum, ast.Scalar:
+			c.names[pascal(def.Name)] = true
+		}
+	}
+	for _, imp := range []string{"bytes", "context", "encoding/json", "fmt", "net/http", "strings"} {
+		g.imports[imp] = true
+	}
+	g.buf.WriteString(goClientTransport)
+
+	for _, op := range ops.Operations {
+		if err := c.operation(op); err != nil {
+			return err
+		}
+	}
+
+	// the types the operations refer to, in the order of the schema
+	for _, def := range doc.Definitions {
+		if c.used[def.Name] {
+			if err := g.definition(def); err != nil {
+				return err
+			}
+		}
+	}
+	return g.write(w, opts.Package)
+}
+
+type clientGen struct {
+	*goGen
+	schema *ast.Schema
+	ops    *ast.QueryDocument
+
+	// used holds the input, enum and scalar types the operations refer to
+	used map[string]bool
+
+	// names holds the identifiers declared by the package and the members of Client
+	names map[string]bool
+}
+
+// goClientNames are the identifiers of the transport, operations must not be named after them.
+var goClientNames = []string{"Client", "NewClient", "Error", "Errors", "Do", "Endpoint", "HTTPClient", "Header"}
+
+// declare reserves the identifier, it fails if it is taken.
+func (c *clientGen) declare(name string) error {
+	if c.names[name] {
+		return fmt.Errorf("name '%s' is already declared", name)
+	}
+	c.names[name] = true
+	return nil
+}
+
+// unique returns the name, suffixed by a number if it is taken, and reserves it.
+func (c *clientGen) unique(name string) string {
+	res := name
+	for i := 2; c.names[res]; i++ {
+		res = fmt.Sprintf("%s%d", name, i)
+	}
+	c.names[res] = true
+	return res
+}
+
+func (c *clientGen) operation(op *ast.OperationDefinition) error {
+	root, err := rootType(c.schema, op)
+	if err != nil {
+		return err
+	}
+	name := pascal(op.Name)
+	for _, n := range []string{name, name + "Document", name + "Variables", name + "Response"} {
+		if err := c.declare(n); err != nil {
+			return fmt.Errorf("operation '%s': %v", op.Name, err)
+		}
+	}
+
+	document := printOperation(op, c.ops.Fragments)
+	fmt.Fprintf(&c.buf, "\n// %sDocument is the document of the %s %s.\n", name, op.Name, op.Operation)
+	if strings.Contains(document, "`") {
+		fmt.Fprintf(&c.buf, "const %sDocument = %q\n", name, document)
+	} else {
+		fmt.Fprintf(&c.buf, "const %sDocument = `%s`\n", name, document)
+	}
+
+	vars := "nil"
+	params := "ctx context.Context"
+	if len(op.VariableDefinitions) > 0 {
+		vars = "vars"
+		params += ", vars " + name + "Variables"
+		fmt.Fprintf(&c.buf, "\n// %sVariables are the variables of the %s %s.\n", name, op.Name, op.Operation)
+		fmt.Fprintf(&c.buf, "type %sVariables struct {\n", name)
+		ids := make(identifiers)
+		for _, v := range op.VariableDefinitions {
+			if err := ids.declare(goName(v.Variable), fmt.Sprintf("variable '%s'", v.Variable)); err != nil {
+				return fmt.Errorf("operation '%s': %v", op.Name, err)
+			}
+			c.use(v.Type.Name())
+			typ, err := c.typ(v.Type)
+			if err != nil {
+				return fmt.Errorf("variable '%s': %v", v.Variable, err)
+			}
+			tag := v.Variable
+			if !v.Type.NonNull {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&c.buf, "%s %s `json:\"%s\"`\n", goName(v.Variable), typ, tag)
+		}
+		c.buf.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&c.buf, "\n// %s sends the %s %s.\n", name, op.Name, op.Operation)
+	fmt.Fprintf(&c.buf, `func (c *Client) %[1]s(%[2]s) (*%[1]sResponse, error) {
+var resp %[1]sResponse
+if err := c.Do(ctx, %[1]sDocument, %[3]q, %[4]s, &resp); err != nil {
+return nil, err
+}
+return &resp, nil
+}
+`, name, params, op.Name, vars)
+
+	fmt.Fprintf(&c.buf, "\n// %sResponse is the data of the response to the %s %s.\n", name, op.Name, op.Operation)
+	return c.result(name+"Response", root, []ast.SelectionSet{op.SelectionSet})
+}
+
+// use marks the named type and the types of its input fields as used.
+func (c *clientGen) use(name string) {
+	def := c.types[name]
+	if def == nil || c.used[name] || builtInScalars[name] {
+		return
+	}
+	c.used[name] = true
+	if def.Kind == ast.InputObject {
+		for _, f := range def.Fields {
+			c.use(f.Type.Name())
+		}
+	}
+}
+
+// resultField is a field of a response struct, the fields under the same key of all possible types are merged.
+type resultField struct {
+	*selectedField
+	def *ast.FieldDefinition
+
+	// optional fields are not selected on all possible types or depend on the skip or include directives
+	optional bool
+}
+
+// result writes the struct of the selection sets on the composite type and the structs of its fields.
+func (c *clientGen) result(name string, def *ast.Definition, sets []ast.SelectionSet) error {
+	possible := []*ast.Definition{def}
+	if def.Kind != ast.Object {
+		possible = c.schema.GetPossibleTypes(def)
+	}
+
+	var fields []*resultField
+	keys := make(map[string]*resultField)
+	counts := make(map[string]int)
+	for _, t := range possible {
+		for _, f := range collectFields(c.schema, c.ops, t, sets) {
+			counts[f.key]++
+			if rf, ok := keys[f.key]; ok {
+				rf.fields = append(rf.fields, f.fields...)
+				rf.optional = rf.optional || f.conditional
+				continue
+			}
+			rf := &resultField{selectedField: f, def: t.Fields.ForName(f.fields[0].Name), optional: f.conditional}
+			keys[f.key] = rf
+			fields = append(fields, rf)
+		}
+	}
+
+	var nested []func() error
+	ids := make(identifiers)
+	fmt.Fprintf(&c.buf, "type %s struct {\n", name)
+	for _, f := range fields {
+		optional := f.optional || counts[f.key] < len(possible)
+		fieldName := goName(f.key)
+		if f.fields[0].Name == "__typename" {
+			fieldName = "Typename"
+		}
+		if err := ids.declare(fieldName, fmt.Sprintf("field '%s'", f.key)); err != nil {
+			return fmt.Errorf("struct '%s': %v", name, err)
+		}
+		if f.fields[0].Name == "__typename" {
+			typ := "string"
+			if optional {
+				typ = "*string"
+			}
+			fmt.Fprintf(&c.buf, "Typename %s `json:\"%s\"`\n", typ, f.key)
+			continue
+		}
+		if f.def == nil {
+			return fmt.Errorf("field '%s.%s' not found", def.Name, f.fields[0].Name)
+		}
+
+		t := c.schema.Types[f.def.Type.Name()]
+		composite := t.Kind != ast.Scalar && t.Kind != ast.Enum
+		nestedName := name + fieldName
+		if composite {
+			nestedName = c.unique(nestedName)
+		}
+		typ := c.resultType(f.def.Type, nestedName)
+		if optional && !strings.HasPrefix(typ, "*") && !strings.HasPrefix(typ, "[]") {
+			typ = "*" + typ
+		}
+		fmt.Fprintf(&c.buf, "%s %s `json:\"%s\"`\n", fieldName, typ, f.key)
+
+		if composite {
+			sets := subselections(f.fields)
+			nested = append(nested, func() error {
+				c.buf.WriteString("\n")
+				return c.result(nestedName, t, sets)
+			})
+		}
+	}
+	c.buf.WriteString("}\n")
+
+	for _, n := range nested {
+		if err := n(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resultType returns the Go type of the field of a response struct, structs of composite types are named as given.
+func (c *clientGen) resultType(t *ast.Type, name string) string {
+	if t.Elem != nil {
+		return "[]" + c.resultType(t.Elem, name)
+	}
+
+	def := c.schema.Types[t.NamedType]
+	switch def.Kind {
+	case ast.Scalar, ast.Enum:
+		c.use(def.Name)
+		if typ, ok := c.scalars[def.Name]; ok {
+			if pkg := c.scalarImports[def.Name]; pkg != "" {
+				c.imports[pkg] = true
+			}
+			name = typ
+		} else {
+			name = pascal(def.Name)
+		}
+	}
+	if !t.NonNull {
+		return "*" + name
+	}
+	return name
+}
+
+// goClientTransport is the HTTP transport of the generated clients.
+const goClientTransport = `
+// Client sends the operations to a GraphQL endpoint over HTTP.
+type Client struct {
+
+	// Endpoint is the URL of the GraphQL endpoint
+	Endpoint string
+
+	// HTTPClient sends the requests, http.DefaultClient unless set
+	HTTPClient *http.Client
+
+	// Header is sent along with each request, e.g. for authorization
+	Header http.Header
+}
+
+// NewClient creates a client of the GraphQL endpoint.
+func NewClient(endpoint string) *Client {
+	return &Client{Endpoint: endpoint, Header: make(http.Header)}
+}
+
+// Error is an error reported by the GraphQL service.
+type Error struct {
+	Message string        ` + "`json:\"message\"`" + `
+	Path    []interface{} ` + "`json:\"path,omitempty\"`" + `
+}
+
+// Errors are the errors of a response, the data is decoded as far as the service resolved it.
+type Errors []Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Do sends the operation document with the variables and decodes the data of the response into data.
+// Errors reported by the service are returned as Errors.
+func (c *Client) Do(ctx context.Context, document, operationName string, variables, data interface{}) error {
+	body, err := json.Marshal(struct {
+		Query         string      ` + "`json:\"query\"`" + `
+		OperationName string      ` + "`json:\"operationName\"`" + `
+		Variables     interface{} ` + "`json:\"variables,omitempty\"`" + `
+	}{document, operationName, variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var r struct {
+		Data   json.RawMessage ` + "`json:\"data\"`" + `
+		Errors Errors          ` + "`json:\"errors\"`" + `
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status '%s'", resp.Status)
+		}
+		return fmt.Errorf("invalid response: %v", err)
+	}
+	if len(r.Data) > 0 && string(r.Data) != "null" {
+		if err := json.Unmarshal(r.Data, data); err != nil {
+			return fmt.Errorf("invalid response data: %v", err)
+		}
+	}
+	if len(r.Errors) > 0 {
+		return r.Errors
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status '%s'", resp.Status)
+	}
+	return nil
+}
+`
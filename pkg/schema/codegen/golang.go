@@ -0,0 +1,318 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	gofmt "go/format"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// GoOptions configures generation of Go code.
+type GoOptions struct {
+
+	// Package is the name of the generated package, model unless set
+	Package string
+
+	// Scalars maps scalar types to Go types, qualified by the import path unless predeclared,
+	// e.g. time.Time or github.com/google/uuid.UUID
+	Scalars map[string]string
+}
+
+var goScalars = map[string]string{
+	"String":  "string",
+	"Int":     "int",
+	"Float":   "float64",
+	"Boolean": "bool",
+	"ID":      "string",
+}
+
+// Go writes Go types of the schema types: structs of the object and input types, typed enums validated when decoded
+// and interfaces implemented by the possible types of the interfaces and unions. The root operation types are left out.
+// Scalars not mapped to Go types become string types, methods implementing json.Marshaler and json.Unmarshaler
+// can be added to them in another file of the package.
+func Go(w io.Writer, doc *ast.SchemaDocument, opts GoOptions) error {
+	if opts.Package == "" {
+		opts.Package = "model"
+	}
+	doc, _ = merge.Documents(doc)
+	g, err := newGoGen(doc, opts.Scalars)
+	if err != nil {
+		return err
+	}
+
+	skip := roots(doc)
+	for _, def := range doc.Definitions {
+		if skip[def.Name] {
+			continue
+		}
+		if err := g.definition(def); err != nil {
+			return err
+		}
+	}
+	return g.write(w, opts.Package)
+}
+
+func newGoGen(doc *ast.SchemaDocument, scalars map[string]string) (*goGen, error) {
+	g := &goGen{
+		types:         make(map[string]*ast.Definition),
+		abstract:      make(map[string][]string),
+		imports:       make(map[string]bool),
+		scalars:       make(map[string]string),
+		scalarImports: make(map[string]string),
+	}
+	for name, t := range goScalars {
+		g.scalars[name] = t
+	}
+	for name, t := range scalars {
+		typ, pkg, err := qualify(t)
+		if err != nil {
+			return nil, fmt.Errorf("scalar '%s': %v", name, err)
+		}
+		g.scalars[name] = typ
+		g.scalarImports[name] = pkg
+	}
+	for _, def := range doc.Definitions {
+		g.types[def.Name] = def
+		g.abstract[def.Name] = append(g.abstract[def.Name], def.Interfaces...)
+		if def.Kind == ast.Union {
+			for _, t := range def.Types {
+				g.abstract[t] = append(g.abstract[t], def.Name)
+			}
+		}
+	}
+	return g, nil
+}
+
+// write writes the generated code as the package, formatted.
+func (g *goGen) write(w io.Writer, pkg string) error {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by graphql-tools, DO NOT EDIT.\n\npackage %s\n", pkg)
+	if len(g.imports) > 0 {
+		var imports []string
+		for p := range g.imports {
+			imports = append(imports, p)
+		}
+		sort.Slice(imports, func(i, j int) bool {
+			if isStd(imports[i]) != isStd(imports[j]) {
+				return isStd(imports[i])
+			}
+			return imports[i] < imports[j]
+		})
+		out.WriteString("\nimport (\n")
+		for i, p := range imports {
+			// the standard library comes first
+			if i > 0 && isStd(imports[i-1]) != isStd(p) {
+				out.WriteString("\n")
+			}
+			fmt.Fprintf(&out, "%q\n", p)
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := gofmt.Source(out.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format generated code: %v", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+func (g *goGen) definition(def *ast.Definition) error {
+	switch def.Kind {
+	case ast.Scalar:
+		g.scalar(def)
+	case ast.Enum:
+		g.enum(def)
+	case ast.Object, ast.InputObject:
+		return g.object(def)
+	case ast.Interface, ast.Union:
+		g.iface(def)
+	}
+	return nil
+}
+
+type goGen struct {
+	buf   bytes.Buffer
+	types map[string]*ast.Definition
+
+	// abstract maps object types to the interfaces and unions they belong to
+	abstract map[string][]string
+
+	imports map[string]bool
+	scalars map[string]string
+
+	// scalarImports maps scalars to the import paths of their Go types
+	scalarImports map[string]string
+}
+
+// qualify turns a type qualified by the import path into one qualified by the package name, e.g. github.com/google/uuid.UUID
+// into uuid.UUID, the import path is returned too.
+func qualify(t string) (string, string, error) {
+	i := strings.LastIndex(t, ".")
+	if i < 0 {
+		return t, "", nil
+	}
+	pkg, name := t[:i], t[i+1:]
+	if pkg == "" || name == "" {
+		return "", "", fmt.Errorf("invalid Go type '%s'", t)
+	}
+	return path.Base(pkg) + "." + name, pkg, nil
+}
+
+func isStd(pkg string) bool {
+	return !strings.Contains(strings.SplitN(pkg, "/", 2)[0], ".")
+}
+
+func (g *goGen) scalar(def *ast.Definition) {
+	if _, ok := g.scalars[def.Name]; ok || builtInScalars[def.Name] {
+		return
+	}
+	name := pascal(def.Name)
+	g.buf.WriteString("\n" + comment("//", def.Description, def.Directives))
+	fmt.Fprintf(&g.buf, "type %s string\n", name)
+}
+
+func (g *goGen) enum(def *ast.Definition) {
+	g.imports["encoding/json"] = true
+	g.imports["fmt"] = true
+
+	name := pascal(def.Name)
+	values := make([]string, len(def.EnumValues))
+	for i, v := range def.EnumValues {
+		values[i] = name + pascal(v.Name)
+	}
+
+	g.buf.WriteString("\n" + comment("//", def.Description, def.Directives))
+	fmt.Fprintf(&g.buf, "type %s string\n\nconst (\n", name)
+	for i, v := range def.EnumValues {
+		g.buf.WriteString(comment("//", v.Description, v.Directives))
+		fmt.Fprintf(&g.buf, "%s %s = %q\n", values[i], name, v.Name)
+	}
+	g.buf.WriteString(")\n")
+
+	fmt.Fprintf(&g.buf, "\n// All%s lists the values of %s.\n", name, name)
+	fmt.Fprintf(&g.buf, "var All%s = []%s{%s}\n", name, name, strings.Join(values, ", "))
+
+	fmt.Fprintf(&g.buf, "\n// IsValid tells whether the value is one of the values of %s.\n", name)
+	fmt.Fprintf(&g.buf, "func (e %s) IsValid() bool {\nswitch e {\ncase %s:\nreturn true\n}\nreturn false\n}\n", name, strings.Join(values, ", "))
+
+	fmt.Fprintf(&g.buf, "\nfunc (e %s) String() string {\nreturn string(e)\n}\n", name)
+
+	fmt.Fprintf(&g.buf, "\n// UnmarshalJSON decodes the value, values other than those of %s are rejected.\n", name)
+	fmt.Fprintf(&g.buf, `func (e *%[1]s) UnmarshalJSON(b []byte) error {
+var s string
+if err := json.Unmarshal(b, &s); err != nil {
+return err
+}
+if !%[1]s(s).IsValid() {
+return fmt.Errorf("invalid %[2]s value '%%s'", s)
+}
+*e = %[1]s(s)
+return nil
+}
+`, name, def.Name)
+}
+
+func (g *goGen) object(def *ast.Definition) error {
+	name := pascal(def.Name)
+	ids := make(identifiers)
+	for _, a := range g.abstract[def.Name] {
+		ids["Is"+pascal(a)] = fmt.Sprintf("the marker method of '%s'", a)
+	}
+
+	g.buf.WriteString("\n" + comment("//", def.Description, def.Directives))
+	fmt.Fprintf(&g.buf, "type %s struct {\n", name)
+	for _, f := range def.Fields {
+		fieldName := goName(f.Name)
+		if err := ids.declare(fieldName, fmt.Sprintf("field '%s'", f.Name)); err != nil {
+			return fmt.Errorf("type '%s': %v", def.Name, err)
+		}
+		typ, err := g.typ(f.Type)
+		if err != nil {
+			return fmt.Errorf("field '%s.%s': %v", def.Name, f.Name, err)
+		}
+		tag := f.Name
+		if def.Kind == ast.InputObject && !f.Type.NonNull {
+			tag += ",omitempty"
+		}
+		g.buf.WriteString(comment("//", f.Description, f.Directives))
+		fmt.Fprintf(&g.buf, "%s %s `json:\"%s\"`\n", fieldName, typ, tag)
+	}
+	g.buf.WriteString("}\n")
+
+	// the marker methods make the struct implement the Go interfaces of its interfaces and unions
+	for _, a := range g.abstract[def.Name] {
+		fmt.Fprintf(&g.buf, "\nfunc (%s) Is%s() {}\n", name, pascal(a))
+	}
+	return nil
+}
+
+// identifiers maps the Go identifiers of the fields and methods of a struct to what they were generated for.
+type identifiers map[string]string
+
+// declare reserves the identifier, it fails if another field or method has it already.
+func (ids identifiers) declare(name, what string) error {
+	if prev, ok := ids[name]; ok {
+		return fmt.Errorf("%s and %s are both named '%s' in Go", prev, what, name)
+	}
+	ids[name] = what
+	return nil
+}
+
+// goName returns the Go identifier of a field, those of names without letters are prefixed by X, e.g. _ becomes X_.
+func goName(name string) string {
+	s := pascal(name)
+	switch {
+	case s == "":
+		return "X" + name
+	case unicode.IsDigit(rune(s[0])):
+		return "X" + s
+	}
+	return s
+}
+
+func (g *goGen) iface(def *ast.Definition) {
+	name := pascal(def.Name)
+	g.buf.WriteString("\n" + comment("//", def.Description, def.Directives))
+	fmt.Fprintf(&g.buf, "type %s interface {\nIs%s()\n}\n", name, name)
+}
+
+// typ returns the Go type of the GraphQL type: nullable values and structs are pointers, lists slices
+// and abstract types interfaces.
+func (g *goGen) typ(t *ast.Type) (string, error) {
+	if t.Elem != nil {
+		elem, err := g.typ(t.Elem)
+		return "[]" + elem, err
+	}
+
+	name, ok := g.scalars[t.NamedType]
+	if pkg := g.scalarImports[t.NamedType]; pkg != "" {
+		g.imports[pkg] = true
+	}
+	if !ok {
+		def := g.types[t.NamedType]
+		if def == nil {
+			return "", fmt.Errorf("type '%s' not found", t.NamedType)
+		}
+		name = pascal(def.Name)
+		switch def.Kind {
+		case ast.Interface, ast.Union:
+			return name, nil
+		case ast.Object, ast.InputObject:
+			// structs may refer to themselves
+			return "*" + name, nil
+		}
+	}
+	if !t.NonNull {
+		return "*" + name, nil
+	}
+	return name, nil
+}
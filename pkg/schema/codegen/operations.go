@@ -0,0 +1,144 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+	"github.com/vektah/gqlparser/validator"
+
+	// registers the validation rules of the specification
+	_ "github.com/vektah/gqlparser/validator/rules"
+)
+
+// LoadOperations parses the operation documents and validates them against the schema as a single document,
+// fragments may be defined in any of them. Operations must be named.
+func LoadOperations(doc *ast.SchemaDocument, sources ...*ast.Source) (*ast.QueryDocument, error) {
+	schema, err := introspection.LoadSchema(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := &ast.QueryDocument{}
+	for _, src := range sources {
+		d, gqlErr := parser.ParseQuery(src)
+		if gqlErr != nil {
+			return nil, gqlErr
+		}
+		ops.Operations = append(ops.Operations, d.Operations...)
+		ops.Fragments = append(ops.Fragments, d.Fragments...)
+	}
+	if errs := validator.Validate(schema, ops); len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(errs.Error()))
+	}
+	for _, op := range ops.Operations {
+		if op.Name == "" {
+			return nil, fmt.Errorf("%s:%d: operations must be named", op.Position.Src.Name, op.Position.Line)
+		}
+	}
+	return ops, nil
+}
+
+// operationType returns the name of the generated type of the operation result, the name suffixed
+// by the kind of the operation, e.g. GetUserQuery.
+func operationType(op *ast.OperationDefinition) string {
+	suffix := strings.Title(string(op.Operation))
+	if strings.HasSuffix(op.Name, suffix) {
+		return op.Name
+	}
+	return op.Name + suffix
+}
+
+// rootType returns the root type of the operation.
+func rootType(schema *ast.Schema, op *ast.OperationDefinition) (*ast.Definition, error) {
+	var def *ast.Definition
+	switch op.Operation {
+	case ast.Query:
+		def = schema.Query
+	case ast.Mutation:
+		def = schema.Mutation
+	case ast.Subscription:
+		def = schema.Subscription
+	}
+	if def == nil {
+		return nil, fmt.Errorf("%s type not found", op.Operation)
+	}
+	return def, nil
+}
+
+// selectedField is a field of a selection set, the fields selected under the same response key are merged.
+type selectedField struct {
+	key    string
+	fields []*ast.Field
+
+	// conditional fields depend on the skip or include directives
+	conditional bool
+}
+
+// collectFields returns the fields the selection sets select on the object type, the fragments applying
+// to the type are expanded.
+func collectFields(schema *ast.Schema, ops *ast.QueryDocument, def *ast.Definition, sets []ast.SelectionSet) []*selectedField {
+	var res []*selectedField
+	keys := make(map[string]*selectedField)
+	var collect func(set ast.SelectionSet, conditional bool)
+	collect = func(set ast.SelectionSet, conditional bool) {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *ast.Field:
+				key := sel.Alias
+				if key == "" {
+					key = sel.Name
+				}
+				f, ok := keys[key]
+				if !ok {
+					f = &selectedField{key: key, conditional: true}
+					keys[key] = f
+					res = append(res, f)
+				}
+				f.fields = append(f.fields, sel)
+				f.conditional = f.conditional && (conditional || isConditional(sel.Directives))
+			case *ast.InlineFragment:
+				if applies(schema, def, sel.TypeCondition) {
+					collect(sel.SelectionSet, conditional || isConditional(sel.Directives))
+				}
+			case *ast.FragmentSpread:
+				f := ops.Fragments.ForName(sel.Name)
+				if f != nil && applies(schema, def, f.TypeCondition) {
+					collect(f.SelectionSet, conditional || isConditional(sel.Directives))
+				}
+			}
+		}
+	}
+	for _, set := range sets {
+		collect(set, false)
+	}
+	return res
+}
+
+// subselections returns the selection sets of the fields.
+func subselections(fields []*ast.Field) []ast.SelectionSet {
+	sets := make([]ast.SelectionSet, len(fields))
+	for i, f := range fields {
+		sets[i] = f.SelectionSet
+	}
+	return sets
+}
+
+func isConditional(list ast.DirectiveList) bool {
+	return list.ForName("skip") != nil || list.ForName("include") != nil
+}
+
+// applies tells whether a fragment with the type condition applies to the object type.
+func applies(schema *ast.Schema, def *ast.Definition, condition string) bool {
+	if condition == "" || condition == def.Name {
+		return true
+	}
+	for _, t := range schema.GetPossibleTypes(schema.Types[condition]) {
+		if t.Name == def.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// printOperation prints the operation followed by the fragments it uses, the document sent to the service.
+func printOperation(op *ast.OperationDefinition, fragments ast.FragmentDefinitionList) string {
+	p := &queryPrinter{fragments: fragments, used: make(map[string]bool)}
+	p.b.WriteString(string(op.Operation) + " " + op.Name)
+	if len(op.VariableDefinitions) > 0 {
+		vars := make([]string, len(op.VariableDefinitions))
+		for i, v := range op.VariableDefinitions {
+			vars[i] = "$" + v.Variable + ": " + v.Type.String()
+			if v.DefaultValue != nil {
+				vars[i] += " = " + format.Value(v.DefaultValue)
+			}
+		}
+		p.b.WriteString("(" + strings.Join(vars, ", ") + ")")
+	}
+	p.directives(op.Directives)
+	p.selectionSet(op.SelectionSet, "")
+
+	// the fragments used by the fragments are appended while printing
+	for i := 0; i < len(p.order); i++ {
+		f := p.order[i]
+		p.b.WriteString("\n\nfragment " + f.Name + " on " + f.TypeCondition)
+		p.directives(f.Directives)
+		p.selectionSet(f.SelectionSet, "")
+	}
+	return p.b.String()
+}
+
+type queryPrinter struct {
+	b         strings.Builder
+	fragments ast.FragmentDefinitionList
+	used      map[string]bool
+	order     []*ast.FragmentDefinition
+}
+
+func (p *queryPrinter) selectionSet(set ast.SelectionSet, indent string) {
+	if len(set) == 0 {
+		return
+	}
+	p.b.WriteString(" {\n")
+	for _, sel := range set {
+		p.b.WriteString(indent + "  ")
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if sel.Alias != "" && sel.Alias != sel.Name {
+				p.b.WriteString(sel.Alias + ": ")
+			}
+			p.b.WriteString(sel.Name)
+			p.arguments(sel.Arguments)
+			p.directives(sel.Directives)
+			p.selectionSet(sel.SelectionSet, indent+"  ")
+		case *ast.InlineFragment:
+			p.b.WriteString("...")
+			if sel.TypeCondition != "" {
+				p.b.WriteString(" on " + sel.TypeCondition)
+			}
+			p.directives(sel.Directives)
+			p.selectionSet(sel.SelectionSet, indent+"  ")
+		case *ast.FragmentSpread:
+			p.b.WriteString("..." + sel.Name)
+			p.directives(sel.Directives)
+			if f := p.fragments.ForName(sel.Name); f != nil && !p.used[f.Name] {
+				p.used[f.Name] = true
+				p.order = append(p.order, f)
+			}
+		}
+		p.b.WriteString("\n")
+	}
+	p.b.WriteString(indent + "}")
+}
+
+func (p *queryPrinter) directives(list ast.DirectiveList) {
+	for _, d := range list {
+		p.b.WriteString(" @" + d.Name)
+		p.arguments(d.Arguments)
+	}
+}
+
+func (p *queryPrinter) arguments(list ast.ArgumentList) {
+	if len(list) == 0 {
+		return
+	}
+	args := make([]string, len(list))
+	for i, a := range list {
+		args[i] = a.Name + ": " + format.Value(a.Value)
+	}
+	p.b.WriteString("(" + strings.Join(args, ", ") + ")")
+}
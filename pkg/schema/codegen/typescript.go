@@ -0,0 +1,241 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// TypeScriptOptions configures generation of TypeScript code.
+type TypeScriptOptions struct {
+
+	// Scalars maps scalar types to TypeScript types, custom scalars are any unless mapped
+	Scalars map[string]string
+}
+
+var tsScalars = map[string]string{
+	"String":  "string",
+	"Int":     "number",
+	"Float":   "number",
+	"Boolean": "boolean",
+	"ID":      "string",
+}
+
+// TypeScript writes TypeScript types of the schema types and, given operations loaded by LoadOperations,
+// the types of the result and the variables of each operation and of each fragment. The result types have exactly
+// the fields selected, under their aliases, and fields selected on abstract types become unions of the possible types.
+// Fields depending on the skip or include directives are optional.
+func TypeScript(w io.Writer, doc *ast.SchemaDocument, ops *ast.QueryDocument, opts TypeScriptOptions) error {
+	schema, err := introspection.LoadSchema(doc)
+	if err != nil {
+		return err
+	}
+	doc, _ = merge.Documents(doc)
+
+	g := &tsGen{
+		schema:  schema,
+		ops:     ops,
+		scalars: make(map[string]string),
+	}
+	for name, t := range tsScalars {
+		g.scalars[name] = t
+	}
+	for name, t := range opts.Scalars {
+		g.scalars[name] = t
+	}
+
+	g.buf.WriteString("// Code generated by graphql-tools, DO NOT EDIT.\n")
+	skip := roots(doc)
+	for _, def := range doc.Definitions {
+		if skip[def.Name] || builtInScalars[def.Name] {
+			continue
+		}
+		g.definition(def)
+	}
+	if ops != nil {
+		for _, f := range ops.Fragments {
+			g.buf.WriteString("\n")
+			fmt.Fprintf(&g.buf, "export type %sFragment = %s;\n", f.Name, g.selection(schema.Types[f.TypeCondition], []ast.SelectionSet{f.SelectionSet}, ""))
+		}
+		for _, op := range ops.Operations {
+			if err := g.operation(op); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = w.Write(g.buf.Bytes())
+	return err
+}
+
+type tsGen struct {
+	buf     bytes.Buffer
+	schema  *ast.Schema
+	ops     *ast.QueryDocument
+	scalars map[string]string
+}
+
+func (g *tsGen) definition(def *ast.Definition) {
+	g.buf.WriteString("\n" + tsDoc("", def.Description, def.Directives))
+	switch def.Kind {
+	case ast.Scalar:
+		t, ok := g.scalars[def.Name]
+		if !ok {
+			t = "any"
+		}
+		fmt.Fprintf(&g.buf, "export type %s = %s;\n", def.Name, t)
+	case ast.Enum:
+		values := make([]string, len(def.EnumValues))
+		for i, v := range def.EnumValues {
+			values[i] = fmt.Sprintf("%q", v.Name)
+		}
+		fmt.Fprintf(&g.buf, "export type %s = %s;\n", def.Name, strings.Join(values, " | "))
+	case ast.Union:
+		fmt.Fprintf(&g.buf, "export type %s = %s;\n", def.Name, strings.Join(def.Types, " | "))
+	case ast.Object, ast.Interface, ast.InputObject:
+		fmt.Fprintf(&g.buf, "export type %s = {\n", def.Name)
+		if def.Kind == ast.Object {
+			fmt.Fprintf(&g.buf, "  __typename?: %q;\n", def.Name)
+		}
+		for _, f := range def.Fields {
+			optional := ""
+			if def.Kind == ast.InputObject && (!f.Type.NonNull || f.DefaultValue != nil) {
+				optional = "?"
+			}
+			g.buf.WriteString(tsDoc("  ", f.Description, f.Directives))
+			fmt.Fprintf(&g.buf, "  %s%s: %s;\n", f.Name, optional, g.typeRef(f.Type, g.named))
+		}
+		g.buf.WriteString("};\n")
+	}
+}
+
+func (g *tsGen) operation(op *ast.OperationDefinition) error {
+	root, err := rootType(g.schema, op)
+	if err != nil {
+		return err
+	}
+	name := operationType(op)
+
+	g.buf.WriteString("\n")
+	if len(op.VariableDefinitions) == 0 {
+		fmt.Fprintf(&g.buf, "export type %sVariables = Record<string, never>;\n", name)
+	} else {
+		fmt.Fprintf(&g.buf, "export type %sVariables = {\n", name)
+		for _, v := range op.VariableDefinitions {
+			optional := ""
+			if !v.Type.NonNull || v.DefaultValue != nil {
+				optional = "?"
+			}
+			fmt.Fprintf(&g.buf, "  %s%s: %s;\n", v.Variable, optional, g.typeRef(v.Type, g.named))
+		}
+		g.buf.WriteString("};\n")
+	}
+
+	g.buf.WriteString("\n")
+	fmt.Fprintf(&g.buf, "export type %s = %s;\n", name, g.selection(root, []ast.SelectionSet{op.SelectionSet}, ""))
+	return nil
+}
+
+// selection returns the type of the result of the selection sets on the composite type.
+func (g *tsGen) selection(def *ast.Definition, sets []ast.SelectionSet, indent string) string {
+	if def.Kind == ast.Object {
+		return g.object(def, sets, indent)
+	}
+
+	var shapes []string
+	seen := make(map[string]bool)
+	for _, t := range g.schema.GetPossibleTypes(def) {
+		s := g.object(t, sets, indent)
+		if !seen[s] {
+			seen[s] = true
+			shapes = append(shapes, s)
+		}
+	}
+	if len(shapes) == 0 {
+		return "never"
+	}
+	return strings.Join(shapes, " | ")
+}
+
+func (g *tsGen) object(def *ast.Definition, sets []ast.SelectionSet, indent string) string {
+	fields := collectFields(g.schema, g.ops, def, sets)
+	if len(fields) == 0 {
+		return "{}"
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, f := range fields {
+		optional := ""
+		if f.conditional {
+			optional = "?"
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, f.key, optional, g.fieldType(def, f, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+func (g *tsGen) fieldType(def *ast.Definition, f *selectedField, indent string) string {
+	name := f.fields[0].Name
+	if name == "__typename" {
+		return fmt.Sprintf("%q", def.Name)
+	}
+	fd := def.Fields.ForName(name)
+	if fd == nil {
+		return "never"
+	}
+	return g.typeRef(fd.Type, func(name string) string {
+		t := g.schema.Types[name]
+		if t.Kind == ast.Scalar || t.Kind == ast.Enum {
+			return g.named(name)
+		}
+		return g.selection(t, subselections(f.fields), indent)
+	})
+}
+
+// typeRef returns the TypeScript type of the GraphQL type, the named type is returned by the function.
+func (g *tsGen) typeRef(t *ast.Type, named func(name string) string) string {
+	var s string
+	if t.Elem != nil {
+		s = "Array<" + g.typeRef(t.Elem, named) + ">"
+	} else {
+		s = named(t.NamedType)
+	}
+	if !t.NonNull {
+		s += " | null"
+	}
+	return s
+}
+
+// named refers to the type of the schema, the built-in scalars are referred to by the TypeScript types.
+func (g *tsGen) named(name string) string {
+	if builtInScalars[name] {
+		return g.scalars[name]
+	}
+	return name
+}
+
+// tsDoc returns the description and deprecation of an element as a JSDoc comment.
+func tsDoc(indent, description string, directives ast.DirectiveList) string {
+	lines := descriptionLines(description)
+	if reason, ok := deprecation(directives); ok {
+		lines = append(lines, "@deprecated "+reason)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, l := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+strings.Replace(l, "*/", "*\\/", -1), " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+	return b.String()
+}
@@ -1,9 +1,12 @@
 package compare
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+
+	"github.com/vektah/gqlparser/ast"
 )
 
 // Compare compares two GraphQL schemas and returns a set of detected changes.
@@ -36,6 +39,30 @@ func Schema(x, y io.Reader) (*Result, error) {
 	return r, nil
 }
 
+// Documents compares two already parsed GraphQL schemas, see Schema.
+func Documents(x, y *ast.SchemaDocument) (*Result, error) {
+	loadSchema := func(name string, doc *ast.SchemaDocument) (*schema, error) {
+		s := newSchema()
+		if err := s.load(doc); err != nil {
+			return nil, fmt.Errorf("unable to load schema '%s': %v", name, err)
+		}
+		return s, nil
+	}
+
+	sx, err := loadSchema("x", x)
+	if err != nil {
+		return nil, err
+	}
+	sy, err := loadSchema("y", y)
+	if err != nil {
+		return nil, err
+	}
+
+	r := new(Result)
+	r.compareSchema(sx, sy)
+	return r, nil
+}
+
 // Result stores the detected changes.
 type Result struct {
 	breaking    []Change
@@ -80,30 +107,61 @@ func (r Result) Changes() []Change {
 	return changes
 }
 
+// MarshalJSON encodes the result as an object with changes grouped by severity level.
+func (r Result) MarshalJSON() ([]byte, error) {
+	nonNil := func(changes []Change) []Change {
+		if changes == nil {
+			return []Change{}
+		}
+		return changes
+	}
+	return json.Marshal(jsonResult{
+		Breaking:    nonNil(r.breaking),
+		Dangerous:   nonNil(r.dangerous),
+		NonBreaking: nonNil(r.nonBreaking),
+	})
+}
+
+// UnmarshalJSON decodes the result encoded by MarshalJSON.
+func (r *Result) UnmarshalJSON(b []byte) error {
+	var v jsonResult
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	r.breaking, r.dangerous, r.nonBreaking = v.Breaking, v.Dangerous, v.NonBreaking
+	return nil
+}
+
+type jsonResult struct {
+	Breaking    []Change `json:"breaking"`
+	Dangerous   []Change `json:"dangerous"`
+	NonBreaking []Change `json:"nonBreaking"`
+}
+
 // Change materialize a schema modification.
 type Change struct {
 
 	// Severity of the change
-	Severity ChangeSeverity
+	Severity ChangeSeverity `json:"severity"`
 
 	// Type of the change
-	Type ChangeType
+	Type ChangeType `json:"type"`
 
 	// Message provides human-readable explanation of the change
-	Message string
+	Message string `json:"message"`
 
 	// Path to the changed item
-	Path string
+	Path string `json:"path"`
 }
 
 // ChangeSeverity defined how serious a change is.
 type ChangeSeverity struct {
 
 	// Level indicates backward compatibility
-	Level ChangeSeverityLevel
+	Level ChangeSeverityLevel `json:"level"`
 
 	// Reason provides human-readable explanation of why the severity is chosen
-	Reason string
+	Reason string `json:"reason,omitempty"`
 }
 
 // Level indicates backward compatibility.
@@ -1,7 +1,9 @@
 package compare
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -565,3 +567,60 @@ func TestSchemaCompare(t *testing.T) {
 		}
 	}
 }
+
+func TestResultJSON(t *testing.T) {
+	x := strings.NewReader("type A { a: String b: Int } enum E { X }")
+	y := strings.NewReader("type A { a: String c: Int } enum E { X Y }")
+	res, err := Schema(x, y)
+	if err != nil {
+		t.Fatalf("unable to process schema: %v", err)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unable to encode result: %v", err)
+	}
+
+	var have Result
+	if err := json.Unmarshal(b, &have); err != nil {
+		t.Fatalf("unable to decode result: %v", err)
+	}
+	if !reflect.DeepEqual(res.Changes(), have.Changes()) {
+		t.Errorf("invalid changes: want %v, have %v", res.Changes(), have.Changes())
+	}
+}
+
+func TestArgumentPaths(t *testing.T) {
+	testData := []struct {
+		name string
+		x, y string
+	}{
+		{
+			name: "Object field argument",
+			x:    "type A { a(b: Int, c: Int = 1, d: Int): String }",
+			y:    "type A { a(b: String, c: Int = 2, e: Int): String }",
+		},
+		{
+			name: "Interface field argument",
+			x:    "interface A { a(b: Int, c: Int = 1, d: Int): String }",
+			y:    "interface A { a(b: String, c: Int = 2, e: Int): String }",
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			res, err := Schema(strings.NewReader(s.x), strings.NewReader(s.y))
+			if err != nil {
+				t.Fatalf("unable to process schema: %v", err)
+			}
+			paths := make(map[string]bool)
+			for _, c := range res.Changes() {
+				paths[c.Path] = true
+			}
+			want := map[string]bool{"A.a.b": true, "A.a.c": true, "A.a.d": true, "A.a.e": true}
+			if !reflect.DeepEqual(want, paths) {
+				t.Errorf("invalid paths: want %v, have %v", want, paths)
+			}
+		})
+	}
+}
@@ -125,7 +125,7 @@ func interfaceFieldArgumentDefaultValueChanged(o *ast.Definition, f *ast.FieldDe
 			Level:  Dangerous,
 			Reason: "Changing the default value for an argument may change the runtime behaviour of a field if it was never provided.",
 		},
-		Path: strings.Join([]string{o.Name, x.Name}, "."),
+		Path: strings.Join([]string{o.Name, f.Name, x.Name}, "."),
 	}
 
 	if x.DefaultValue == nil {
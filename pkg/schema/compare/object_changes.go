@@ -151,7 +151,7 @@ func objectFieldArgumentDefaultValueChanged(o *ast.Definition, f *ast.FieldDefin
 			Level:  Dangerous,
 			Reason: "Changing the default value for an argument may change the runtime behaviour of a field if it was never provided.",
 		},
-		Path: strings.Join([]string{o.Name, x.Name}, "."),
+		Path: strings.Join([]string{o.Name, f.Name, x.Name}, "."),
 	}
 
 	if x.DefaultValue == nil {
@@ -29,6 +29,10 @@ func (s *schema) parse(sdl string) error {
 		return fmt.Errorf("unable to parse schema: %v", err)
 	}
 
+	return s.load(doc)
+}
+
+func (s *schema) load(doc *ast.SchemaDocument) error {
 	for _, def := range doc.Schema {
 		if err := s.processSchemaDefinition(def); err != nil {
 			return err
@@ -0,0 +1,209 @@
+package compare
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version of a schema.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a version in the MAJOR.MINOR.PATCH form, optionally prefixed with 'v'.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version '%s': expected MAJOR.MINOR.PATCH", s)
+	}
+
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version '%s': '%s' is not a non-negative number", s, p)
+		}
+		nums[i] = n
+	}
+
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Bump returns the version incremented by given increment.
+func (v Version) Bump(inc VersionIncrement) Version {
+	switch inc {
+	case MajorIncrement:
+		return Version{Major: v.Major + 1}
+	case MinorIncrement:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	case PatchIncrement:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	default:
+		return v
+	}
+}
+
+// VersionIncrement indicates which part of a version a change requires to increment.
+type VersionIncrement int
+
+const (
+	// NoIncrement keeps the version as it is
+	NoIncrement VersionIncrement = iota
+
+	// PatchIncrement increments the patch version
+	PatchIncrement
+
+	// MinorIncrement increments the minor version
+	MinorIncrement
+
+	// MajorIncrement increments the major version
+	MajorIncrement
+)
+
+var versionIncrementNames = map[VersionIncrement]string{
+	NoIncrement:    "none",
+	PatchIncrement: "patch",
+	MinorIncrement: "minor",
+	MajorIncrement: "major",
+}
+
+// ParseVersionIncrement parses one of 'none', 'patch', 'minor' or 'major'.
+func ParseVersionIncrement(s string) (VersionIncrement, error) {
+	for inc, name := range versionIncrementNames {
+		if strings.EqualFold(name, s) {
+			return inc, nil
+		}
+	}
+	return NoIncrement, fmt.Errorf("invalid version increment '%s'", s)
+}
+
+func (i VersionIncrement) String() string {
+	if name, ok := versionIncrementNames[i]; ok {
+		return name
+	}
+	return fmt.Sprintf("VersionIncrement(%d)", int(i))
+}
+
+// changeTypes lists the types of changes the comparison reports.
+var changeTypes = []ChangeType{
+	DirectiveAdded,
+	DirectiveRemoved,
+	DirectiveDescriptionChanged,
+	DirectiveLocationAdded,
+	DirectiveLocationRemoved,
+	DirectiveArgumentAdded,
+	DirectiveArgumentRemoved,
+	DirectiveArgumentDescriptionChanged,
+	DirectiveArgumentDefaultValueChanged,
+	DirectiveArgumentTypeChanged,
+	EnumValueAdded,
+	EnumValueRemoved,
+	InputFieldAdded,
+	InputFieldRemoved,
+	InputFieldDescriptionChanged,
+	InputFieldDefaultValueChanged,
+	InputFieldTypeChanged,
+	InterfaceTypeFieldAdded,
+	InterfaceTypeFieldRemoved,
+	InterfaceTypeFieldDescriptionChanged,
+	InterfaceTypeFieldTypeChanged,
+	InterfaceTypeFieldArgumentAdded,
+	InterfaceTypeFieldArgumentRemoved,
+	InterfaceTypeFieldArgumentDescriptionChanged,
+	InterfaceTypeFieldArgumentDefaultValueChanged,
+	InterfaceTypeFieldArgumentTypeChanged,
+	ObjectTypeInterfaceAdded,
+	ObjectTypeInterfaceRemoved,
+	ObjectTypeFieldAdded,
+	ObjectTypeFieldRemoved,
+	ObjectTypeFieldDescriptionChanged,
+	ObjectTypeFieldTypeChanged,
+	ObjectTypeFieldArgumentAdded,
+	ObjectTypeFieldArgumentRemoved,
+	ObjectTypeFieldArgumentDescriptionChanged,
+	ObjectTypeFieldArgumentDefaultValueChanged,
+	ObjectTypeFieldArgumentTypeChanged,
+	SchemaQueryTypeChanged,
+	SchemaMutationTypeChanged,
+	SchemaMutationTypeRemoved,
+	SchemaSubscriptionTypeChanged,
+	SchemaSubscriptionTypeRemoved,
+	TypeAdded,
+	TypeRemoved,
+	TypeKindChanged,
+	TypeDescriptionChanged,
+	UnionMemberRemoved,
+	UnionMemberAdded,
+}
+
+// ParseChangeType parses the name of one of the change types, e.g. 'OBJECT_TYPE_FIELD_REMOVED'.
+func ParseChangeType(s string) (ChangeType, error) {
+	for _, t := range changeTypes {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown change type '%s'", s)
+}
+
+// VersionRules define which version increment a change requires.
+// A rule for the change type takes precedence over the rule for the change severity level.
+type VersionRules struct {
+
+	// Levels maps severity levels to increments
+	Levels map[ChangeSeverityLevel]VersionIncrement
+
+	// Types maps change types to increments
+	Types map[ChangeType]VersionIncrement
+}
+
+// DefaultVersionRules requires a major version for breaking changes, a patch version for description
+// changes and a minor version for anything else.
+func DefaultVersionRules() VersionRules {
+	return VersionRules{
+		Levels: map[ChangeSeverityLevel]VersionIncrement{
+			Breaking:    MajorIncrement,
+			Dangerous:   MinorIncrement,
+			NonBreaking: MinorIncrement,
+		},
+		Types: map[ChangeType]VersionIncrement{
+			TypeDescriptionChanged:                       PatchIncrement,
+			DirectiveDescriptionChanged:                  PatchIncrement,
+			DirectiveArgumentDescriptionChanged:          PatchIncrement,
+			InputFieldDescriptionChanged:                 PatchIncrement,
+			InterfaceTypeFieldDescriptionChanged:         PatchIncrement,
+			InterfaceTypeFieldArgumentDescriptionChanged: PatchIncrement,
+			ObjectTypeFieldDescriptionChanged:            PatchIncrement,
+			ObjectTypeFieldArgumentDescriptionChanged:    PatchIncrement,
+		},
+	}
+}
+
+// Increment returns the increment required by the given change.
+func (rules VersionRules) Increment(c Change) VersionIncrement {
+	if inc, ok := rules.Types[c.Type]; ok {
+		return inc
+	}
+	return rules.Levels[c.Severity.Level]
+}
+
+// VersionIncrement returns the highest increment required by the detected changes.
+func (r Result) VersionIncrement(rules VersionRules) VersionIncrement {
+	inc := NoIncrement
+	for _, c := range r.Changes() {
+		if i := rules.Increment(c); i > inc {
+			inc = i
+		}
+	}
+	return inc
+}
+
+// RecommendVersion returns the version following the current one according to the detected changes.
+func (r Result) RecommendVersion(current Version, rules VersionRules) Version {
+	return current.Bump(r.VersionIncrement(rules))
+}
@@ -0,0 +1,96 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecommendVersion(t *testing.T) {
+	testData := []struct {
+		name  string
+		x, y  string
+		rules VersionRules
+		want  string
+	}{
+		{
+			name:  "No change keeps the version",
+			x:     "type A { a: String }",
+			y:     "type A { a: String }",
+			rules: DefaultVersionRules(),
+			want:  "1.4.2",
+		},
+		{
+			name:  "Description change increments patch version",
+			x:     "type A { a: String }",
+			y:     `type A { "A" a: String }`,
+			rules: DefaultVersionRules(),
+			want:  "1.4.3",
+		},
+		{
+			name:  "Addition increments minor version",
+			x:     "type A { a: String }",
+			y:     "type A { a: String b: Int }",
+			rules: DefaultVersionRules(),
+			want:  "1.5.0",
+		},
+		{
+			name:  "Dangerous change increments minor version",
+			x:     "enum E { X }",
+			y:     "enum E { X Y }",
+			rules: DefaultVersionRules(),
+			want:  "1.5.0",
+		},
+		{
+			name:  "Breaking change increments major version",
+			x:     `type A { a: String b: Int } "B" type B { b: Int }`,
+			y:     "type A { a: String }",
+			rules: DefaultVersionRules(),
+			want:  "2.0.0",
+		},
+		{
+			name: "Rule for change type takes precedence",
+			x:    "enum E { X }",
+			y:    "enum E { X Y }",
+			rules: VersionRules{
+				Levels: map[ChangeSeverityLevel]VersionIncrement{Dangerous: MinorIncrement},
+				Types:  map[ChangeType]VersionIncrement{EnumValueAdded: MajorIncrement},
+			},
+			want: "2.0.0",
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			res, err := Schema(strings.NewReader(s.x), strings.NewReader(s.y))
+			if err != nil {
+				t.Fatalf("unable to process schema: %v", err)
+			}
+			v, err := ParseVersion("v1.4.2")
+			if err != nil {
+				t.Fatalf("unable to parse version: %v", err)
+			}
+			if have := res.RecommendVersion(v, s.rules).String(); s.want != have {
+				t.Errorf("invalid version: want %q, have %q", s.want, have)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	for _, s := range []string{"", "1", "1.2", "1.2.x", "1.-2.3", "1.2.3.4"} {
+		if _, err := ParseVersion(s); err == nil {
+			t.Errorf("version %q should be invalid", s)
+		}
+	}
+}
+
+func TestParseChangeType(t *testing.T) {
+	if ct, err := ParseChangeType("OBJECT_TYPE_FIELD_REMOVED"); err != nil || ct != ObjectTypeFieldRemoved {
+		t.Errorf("invalid change type: %v, %v", ct, err)
+	}
+	for _, s := range []string{"", "FIELD_REMOVD", "object_type_field_removed", "BREAKING"} {
+		if _, err := ParseChangeType(s); err == nil {
+			t.Errorf("change type %q should be invalid", s)
+		}
+	}
+}
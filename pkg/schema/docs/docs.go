@@ -0,0 +1,312 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Format of the generated documentation.
+type Format string
+
+const (
+	// HTML generates a static site with a client-side search
+	HTML = Format("html")
+
+	// Markdown generates pages suitable for repository browsers and static site generators
+	Markdown = Format("markdown")
+)
+
+// Options control the generated documentation.
+type Options struct {
+
+	// Format of the pages, HTML unless set
+	Format Format
+
+	// Title of the index page, "Schema" unless set
+	Title string
+}
+
+// File is a generated file, its name is relative to the output directory.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// SearchIndexFile lists the documented types, fields, enum values and directives along with links to them.
+const SearchIndexFile = "search-index.json"
+
+// Entry of the search index.
+type Entry struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// Generate documents the schema, extensions included. It generates an index page listing the types
+// and directives, a page per type with its fields, arguments, enum values or possible types,
+// the types implementing an interface and the fields using the type, and a search index.
+func Generate(doc *ast.SchemaDocument, opts Options) ([]File, error) {
+	if opts.Format == "" {
+		opts.Format = HTML
+	}
+	if opts.Title == "" {
+		opts.Title = "Schema"
+	}
+
+	var ext string
+	switch opts.Format {
+	case HTML:
+		ext = ".html"
+	case Markdown:
+		ext = ".md"
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'", opts.Format)
+	}
+
+	s := newSite(doc, opts.Title, ext)
+	var r renderer = newHTMLRenderer(s)
+	if opts.Format == Markdown {
+		r = newMarkdownRenderer(s)
+	}
+
+	var files []File
+	add := func(name string, render func(*bytes.Buffer) error) error {
+		var buf bytes.Buffer
+		if err := render(&buf); err != nil {
+			return fmt.Errorf("unable to render '%s': %v", name, err)
+		}
+		files = append(files, File{Name: name, Content: buf.Bytes()})
+		return nil
+	}
+
+	if err := add(s.indexPage(), r.renderIndex); err != nil {
+		return nil, err
+	}
+	for _, t := range s.Types {
+		t := t
+		if err := add(s.page(t.Name), func(buf *bytes.Buffer) error { return r.renderType(buf, t) }); err != nil {
+			return nil, err
+		}
+	}
+
+	index, err := json.MarshalIndent(s.searchIndex(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, File{Name: SearchIndexFile, Content: index})
+	files = append(files, r.assets(index)...)
+	return files, nil
+}
+
+// Write stores the files in the directory, the directory is created if missing.
+func Write(dir string, files []File) error {
+	for _, f := range files {
+		name := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(name, f.Content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderer renders the pages in a particular format.
+type renderer interface {
+	renderIndex(buf *bytes.Buffer) error
+	renderType(buf *bytes.Buffer, t *typeDoc) error
+	assets(searchIndex []byte) []File
+}
+
+type site struct {
+	Title      string
+	Types      []*typeDoc
+	Directives ast.DirectiveDefinitionList
+	Roots      []*ast.OperationTypeDefinition
+
+	ext   string
+	types map[string]*typeDoc
+	pages map[string]string
+}
+
+type typeDoc struct {
+	*ast.Definition
+
+	// ImplementedBy lists types implementing the interface
+	ImplementedBy []string
+
+	// UsedBy lists fields and arguments referring to the type
+	UsedBy []usage
+}
+
+type usage struct {
+	Type  string
+	Field string
+	Path  string
+}
+
+var kindLabels = map[ast.DefinitionKind]string{
+	ast.Object:      "Object",
+	ast.Interface:   "Interface",
+	ast.Union:       "Union",
+	ast.Enum:        "Enum",
+	ast.InputObject: "Input object",
+	ast.Scalar:      "Scalar",
+}
+
+var kindOrder = []ast.DefinitionKind{ast.Object, ast.Interface, ast.Union, ast.Enum, ast.InputObject, ast.Scalar}
+
+func newSite(doc *ast.SchemaDocument, title, ext string) *site {
+	doc, _ = merge.Documents(doc)
+
+	s := &site{
+		Title: title,
+		ext:   ext,
+		types: make(map[string]*typeDoc),
+		pages: make(map[string]string),
+	}
+	for _, def := range doc.Definitions {
+		t := &typeDoc{Definition: def}
+		s.Types = append(s.Types, t)
+		s.types[def.Name] = t
+	}
+	sort.Slice(s.Types, func(i, j int) bool {
+		return s.Types[i].Name < s.Types[j].Name
+	})
+
+	// page names must differ in more than case on case-insensitive file systems, clashing ones get a suffix
+	// which no GraphQL name can contain
+	taken := map[string]bool{"index": true}
+	for _, t := range s.Types {
+		page := t.Name
+		for i := 2; taken[strings.ToLower(page)]; i++ {
+			page = fmt.Sprintf("%s-%d", t.Name, i)
+		}
+		taken[strings.ToLower(page)] = true
+		s.pages[t.Name] = page
+	}
+
+	s.Directives = append(s.Directives, doc.Directives...)
+	sort.Slice(s.Directives, func(i, j int) bool {
+		return s.Directives[i].Name < s.Directives[j].Name
+	})
+
+	for _, def := range doc.Schema {
+		s.Roots = append(s.Roots, def.OperationTypes...)
+	}
+	if len(doc.Schema) == 0 {
+		for _, op := range []ast.Operation{ast.Query, ast.Mutation, ast.Subscription} {
+			name := strings.Title(string(op))
+			if s.types[name] != nil {
+				s.Roots = append(s.Roots, &ast.OperationTypeDefinition{Operation: op, Type: name})
+			}
+		}
+	}
+
+	use := func(name string, u usage) {
+		if t := s.types[name]; t != nil {
+			t.UsedBy = append(t.UsedBy, u)
+		}
+	}
+	for _, t := range s.Types {
+		for _, f := range t.Fields {
+			use(f.Type.Name(), usage{Type: t.Name, Field: f.Name, Path: t.Name + "." + f.Name})
+			for _, a := range f.Arguments {
+				use(a.Type.Name(), usage{Type: t.Name, Field: f.Name, Path: t.Name + "." + f.Name + "(" + a.Name + ")"})
+			}
+		}
+		for _, name := range t.Interfaces {
+			if i := s.types[name]; i != nil {
+				i.ImplementedBy = append(i.ImplementedBy, t.Name)
+			}
+		}
+	}
+	return s
+}
+
+// page returns the file name of the type page.
+func (s *site) page(name string) string {
+	return s.pages[name] + s.ext
+}
+
+// indexPage returns the file name of the index page.
+func (s *site) indexPage() string {
+	return "index" + s.ext
+}
+
+// anchor returns the fragment of the link to a field, an enum value or a directive, Markdown renderers
+// derive the fragments from the headings in lower case.
+func (s *site) anchor(name string) string {
+	if s.ext == ".md" {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// defined reports whether the type has a page, built-in scalars have none unless redefined.
+func (s *site) defined(name string) bool {
+	return s.types[name] != nil
+}
+
+// kinds groups the types per kind in the order of kindOrder.
+func (s *site) kinds() []kindGroup {
+	var groups []kindGroup
+	for _, k := range kindOrder {
+		g := kindGroup{Label: kindLabels[k] + "s"}
+		for _, t := range s.Types {
+			if t.Kind == k {
+				g.Types = append(g.Types, t)
+			}
+		}
+		if len(g.Types) > 0 {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+type kindGroup struct {
+	Label string
+	Types []*typeDoc
+}
+
+func (s *site) searchIndex() []Entry {
+	entries := []Entry{}
+	for _, t := range s.Types {
+		page := s.page(t.Name)
+		entries = append(entries, Entry{Name: t.Name, Kind: kindLabels[t.Kind], Description: t.Description, URL: page})
+		for _, f := range t.Fields {
+			entries = append(entries, Entry{Name: t.Name + "." + f.Name, Kind: "Field", Description: f.Description, URL: page + "#" + s.anchor(f.Name)})
+		}
+		for _, v := range t.EnumValues {
+			entries = append(entries, Entry{Name: t.Name + "." + v.Name, Kind: "Enum value", Description: v.Description, URL: page + "#" + s.anchor(v.Name)})
+		}
+	}
+	for _, d := range s.Directives {
+		entries = append(entries, Entry{Name: "@" + d.Name, Kind: "Directive", Description: d.Description, URL: s.indexPage() + "#" + s.anchor(d.Name)})
+	}
+	return entries
+}
+
+// deprecation returns the reason of the deprecation, or an empty string if the item is not deprecated.
+func deprecation(list ast.DirectiveList) string {
+	d := list.ForName("deprecated")
+	if d == nil {
+		return ""
+	}
+	if reason := d.Arguments.ForName("reason"); reason != nil && reason.Value != nil {
+		return reason.Value.Raw
+	}
+	return "No longer supported"
+}
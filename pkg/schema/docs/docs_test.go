@@ -0,0 +1,168 @@
+package docs
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const schema = `
+type Query {
+  "Finds a hero"
+  hero(episode: Episode = NEW_HOPE): Character
+  old: String @deprecated(reason: "Use hero")
+}
+interface Character { name: String friends: [Character!] }
+type Human implements Character { name: String friends: [Character!] }
+enum Episode { NEW_HOPE EMPIRE @deprecated }
+extend enum Episode { JEDI }
+directive @auth(role: String) on FIELD_DEFINITION
+`
+
+func TestGenerate(t *testing.T) {
+	testData := []struct {
+		name  string
+		opts  Options
+		files []string
+		want  map[string][]string
+	}{
+		{
+			name:  "HTML",
+			files: []string{"index.html", "Character.html", "Episode.html", "Human.html", "Query.html", SearchIndexFile, "search.js"},
+			want: map[string][]string{
+				"index.html": {
+					`<li>query: <a href="Query.html">Query</a></li>`,
+					`<dt id="auth"><code>@auth</code> on FIELD_DEFINITION</dt>`,
+					`<script src="search.js"></script>`,
+				},
+				"Query.html": {
+					`<dt id="hero"><code>hero: <a href="Character.html">Character</a></code></dt>`,
+					`<p class="description">Finds a hero</p>`,
+					`<code>episode: <a href="Episode.html">Episode</a> = NEW_HOPE</code>`,
+					`<p class="deprecated">Deprecated: Use hero</p>`,
+				},
+				"Character.html": {
+					`<code>friends: [<a href="Character.html">Character</a>!]</code>`,
+					"<h2>Implemented by</h2>\n<ul>\n<li><a href=\"Human.html\">Human</a></li>",
+					`<li><a href="Human.html#friends">Human.friends</a></li>`,
+				},
+				"Episode.html": {
+					`<dt id="JEDI"><code>JEDI</code></dt>`,
+					`<p class="deprecated">Deprecated: No longer supported</p>`,
+					`<li><a href="Query.html#hero">Query.hero(episode)</a></li>`,
+				},
+				"search.js": {`"url": "Query.html#hero"`},
+			},
+		},
+		{
+			name:  "Markdown",
+			opts:  Options{Format: Markdown, Title: "Star Wars"},
+			files: []string{"index.md", "Character.md", "Episode.md", "Human.md", "Query.md", SearchIndexFile},
+			want: map[string][]string{
+				"index.md": {"# Star Wars\n", "- query: [Query](Query.md)\n", "### auth\n\n`@auth` on FIELD_DEFINITION\n"},
+				"Query.md": {
+					"### hero\n\n`hero`: [Character](Character.md)\n\nFinds a hero\n",
+					"- `episode`: [Episode](Episode.md) = `NEW_HOPE`\n",
+					"> **Deprecated:** Use hero\n",
+				},
+				"Character.md":  {"`friends`: \\[[Character](Character.md)!\\]\n", "## Implemented by\n\n- [Human](Human.md)\n"},
+				SearchIndexFile: {`"url": "Query.md#hero"`},
+			},
+		},
+	}
+
+	doc := parseSchema(t)
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			files, err := Generate(doc, s.opts)
+			if err != nil {
+				t.Fatalf("unable to generate documentation: %v", err)
+			}
+
+			var names []string
+			content := make(map[string]string)
+			for _, f := range files {
+				names = append(names, f.Name)
+				content[f.Name] = string(f.Content)
+			}
+			if !reflect.DeepEqual(s.files, names) {
+				t.Errorf("invalid files: want %q, have %q", s.files, names)
+			}
+			for name, parts := range s.want {
+				for _, part := range parts {
+					if !strings.Contains(content[name], part) {
+						t.Errorf("%s does not contain %q:\n%s", name, part, content[name])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	doc := parseSchema(t)
+	files, err := Generate(doc, Options{})
+	if err != nil {
+		t.Fatalf("unable to generate documentation: %v", err)
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.Name == SearchIndexFile {
+			if err := json.Unmarshal(f.Content, &entries); err != nil {
+				t.Fatalf("unable to read search index: %v", err)
+			}
+		}
+	}
+	if len(entries) != 14 {
+		t.Errorf("invalid number of entries: %d", len(entries))
+	}
+	want := Entry{Name: "Query.hero", Kind: "Field", Description: "Finds a hero", URL: "Query.html#hero"}
+	for _, e := range entries {
+		if e.Name == want.Name && e != want {
+			t.Errorf("invalid entry: want %+v, have %+v", want, e)
+		}
+	}
+}
+
+func TestPageNames(t *testing.T) {
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Input: `type Query { a: Index b: User c: USER } type Index { b: String } type User { a: String } type USER { b: String }`})
+	if gqlErr != nil {
+		t.Fatalf("unable to parse schema: %v", gqlErr)
+	}
+	files, err := Generate(doc, Options{})
+	if err != nil {
+		t.Fatalf("unable to generate documentation: %v", err)
+	}
+
+	content := make(map[string]string)
+	for _, f := range files {
+		content[f.Name] = string(f.Content)
+	}
+	if !strings.Contains(content["index.html"], "<h1>Schema</h1>") {
+		t.Errorf("index page is overwritten:\n%s", content["index.html"])
+	}
+	for _, link := range []string{`<a href="Index-2.html">Index</a>`, `<a href="USER.html">USER</a>`, `<a href="User-2.html">User</a>`} {
+		if !strings.Contains(content["Query.html"], link) {
+			t.Errorf("link %s not found:\n%s", link, content["Query.html"])
+		}
+	}
+	if !strings.Contains(content["Index-2.html"], `<a href="index.html">Schema</a>`) {
+		t.Errorf("invalid page of type Index:\n%s", content["Index-2.html"])
+	}
+	if !strings.Contains(content["User-2.html"], `<title>User - Schema</title>`) {
+		t.Errorf("invalid page of type User:\n%s", content["User-2.html"])
+	}
+}
+
+func parseSchema(t *testing.T) *ast.SchemaDocument {
+	doc, err := parser.ParseSchema(&ast.Source{Input: schema})
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+	return doc
+}
@@ -0,0 +1,181 @@
+package docs
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/vektah/gqlparser/ast"
+)
+
+type htmlRenderer struct {
+	site *site
+	tmpl *template.Template
+}
+
+func newHTMLRenderer(s *site) *htmlRenderer {
+	link := func(name string) template.HTML {
+		if !s.defined(name) {
+			return template.HTML(template.HTMLEscapeString(name))
+		}
+		return template.HTML(`<a href="` + template.HTMLEscapeString(s.page(name)) + `">` + template.HTMLEscapeString(name) + `</a>`)
+	}
+	var typeRef func(t *ast.Type) template.HTML
+	typeRef = func(t *ast.Type) template.HTML {
+		var ref template.HTML
+		if t.Elem != nil {
+			ref = "[" + typeRef(t.Elem) + "]"
+		} else {
+			ref = link(t.NamedType)
+		}
+		if t.NonNull {
+			ref += "!"
+		}
+		return ref
+	}
+
+	funcs := template.FuncMap{
+		"link":        link,
+		"typeRef":     typeRef,
+		"page":        s.page,
+		"indexPage":   s.indexPage,
+		"anchor":      s.anchor,
+		"value":       format.Value,
+		"deprecation": deprecation,
+		"kind":        func(k ast.DefinitionKind) string { return kindLabels[k] },
+		"kinds":       s.kinds,
+	}
+	return &htmlRenderer{
+		site: s,
+		tmpl: template.Must(template.New("docs").Funcs(funcs).Parse(htmlTemplates)),
+	}
+}
+
+func (r *htmlRenderer) renderIndex(buf *bytes.Buffer) error {
+	return r.tmpl.ExecuteTemplate(buf, "index", r.site)
+}
+
+func (r *htmlRenderer) renderType(buf *bytes.Buffer, t *typeDoc) error {
+	return r.tmpl.ExecuteTemplate(buf, "type", struct {
+		Site *site
+		Type *typeDoc
+	}{r.site, t})
+}
+
+func (r *htmlRenderer) assets(searchIndex []byte) []File {
+	js := "var searchIndex = " + string(searchIndex) + ";\n" + searchScript
+	return []File{{Name: "search.js", Content: []byte(js)}}
+}
+
+// searchScript filters the search index by the query typed into the search box of the index page,
+// the index is embedded in the script so that the site works from the file system too.
+const searchScript = `
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  input.addEventListener("input", function () {
+    var query = input.value.toLowerCase();
+    results.innerHTML = "";
+    if (query.length < 2) {
+      return;
+    }
+    searchIndex.filter(function (e) {
+      return e.name.toLowerCase().indexOf(query) >= 0 ||
+        (e.description || "").toLowerCase().indexOf(query) >= 0;
+    }).slice(0, 50).forEach(function (e) {
+      var li = document.createElement("li");
+      var a = document.createElement("a");
+      a.href = e.url;
+      a.textContent = e.name;
+      li.appendChild(a);
+      li.appendChild(document.createTextNode(" " + e.kind));
+      results.appendChild(li);
+    });
+  });
+})();
+`
+
+const htmlTemplates = `
+{{define "header"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; padding: 0 1em; line-height: 1.5; }
+.description { white-space: pre-line; }
+.deprecated { color: #a33; }
+.kind { color: #777; font-size: 60%; font-weight: normal; }
+dt { margin-top: 1em; }
+code { font-size: 110%; }
+</style>
+</head>
+<body>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+
+{{define "description"}}{{with .Description}}<p class="description">{{.}}</p>
+{{end}}{{with deprecation .Directives}}<p class="deprecated">Deprecated: {{.}}</p>
+{{end}}{{end}}
+
+{{define "arguments"}}{{if .}}<dl>
+{{range .}}<dt><code>{{.Name}}: {{typeRef .Type}}{{with .DefaultValue}} = {{value .}}{{end}}</code></dt>
+<dd>{{template "description" .}}</dd>
+{{end}}</dl>
+{{end}}{{end}}
+
+{{define "index"}}{{template "header" .Title}}<h1>{{.Title}}</h1>
+<input id="search" type="search" placeholder="Search" autofocus>
+<ul id="results"></ul>
+{{with .Roots}}<h2>Operations</h2>
+<ul>
+{{range .}}<li>{{.Operation}}: {{link .Type}}</li>
+{{end}}</ul>
+{{end}}{{range kinds}}<h2>{{.Label}}</h2>
+<ul>
+{{range .Types}}<li>{{link .Name}}</li>
+{{end}}</ul>
+{{end}}{{with .Directives}}<h2>Directives</h2>
+<dl>
+{{range .}}<dt id="{{.Name}}"><code>@{{.Name}}</code> on {{range $i, $l := .Locations}}{{if $i}}, {{end}}{{$l}}{{end}}</dt>
+<dd>{{with .Description}}<p class="description">{{.}}</p>
+{{end}}{{template "arguments" .Arguments}}</dd>
+{{end}}</dl>
+{{end}}<script src="search.js"></script>
+{{template "footer"}}{{end}}
+
+{{define "type"}}{{template "header" printf "%s - %s" .Type.Name .Site.Title}}<p><a href="{{indexPage}}">{{.Site.Title}}</a></p>
+{{with .Type}}<h1>{{.Name}} <span class="kind">{{kind .Kind}}</span></h1>
+{{with .Description}}<p class="description">{{.}}</p>
+{{end}}{{with .Interfaces}}<h2>Implements</h2>
+<ul>
+{{range .}}<li>{{link .}}</li>
+{{end}}</ul>
+{{end}}{{with .Fields}}<h2>Fields</h2>
+<dl>
+{{range .}}<dt id="{{.Name}}"><code>{{.Name}}: {{typeRef .Type}}{{with .DefaultValue}} = {{value .}}{{end}}</code></dt>
+<dd>{{template "description" .}}{{with .Arguments}}<h4>Arguments</h4>
+{{template "arguments" .}}{{end}}</dd>
+{{end}}</dl>
+{{end}}{{with .EnumValues}}<h2>Values</h2>
+<dl>
+{{range .}}<dt id="{{.Name}}"><code>{{.Name}}</code></dt>
+<dd>{{template "description" .}}</dd>
+{{end}}</dl>
+{{end}}{{with .Types}}<h2>Possible types</h2>
+<ul>
+{{range .}}<li>{{link .}}</li>
+{{end}}</ul>
+{{end}}{{with .ImplementedBy}}<h2>Implemented by</h2>
+<ul>
+{{range .}}<li>{{link .}}</li>
+{{end}}</ul>
+{{end}}{{with .UsedBy}}<h2>Used by</h2>
+<ul>
+{{range .}}<li><a href="{{page .Type}}#{{anchor .Field}}">{{.Path}}</a></li>
+{{end}}</ul>
+{{end}}{{end}}{{template "footer"}}{{end}}
+`
@@ -0,0 +1,146 @@
+package docs
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/vektah/gqlparser/ast"
+)
+
+type markdownRenderer struct {
+	site *site
+	tmpl *template.Template
+}
+
+func newMarkdownRenderer(s *site) *markdownRenderer {
+	link := func(name string) string {
+		if !s.defined(name) {
+			return name
+		}
+		return "[" + name + "](" + s.page(name) + ")"
+	}
+	var typeRef func(t *ast.Type) string
+	typeRef = func(t *ast.Type) string {
+		var ref string
+		if t.Elem != nil {
+			ref = `\[` + typeRef(t.Elem) + `\]`
+		} else {
+			ref = link(t.NamedType)
+		}
+		if t.NonNull {
+			ref += "!"
+		}
+		return ref
+	}
+
+	funcs := template.FuncMap{
+		"link":        link,
+		"typeRef":     typeRef,
+		"page":        s.page,
+		"indexPage":   s.indexPage,
+		"anchor":      s.anchor,
+		"value":       format.Value,
+		"deprecation": deprecation,
+		"kind":        func(k ast.DefinitionKind) string { return kindLabels[k] },
+		"kinds":       s.kinds,
+		"quote": func(s string) string {
+			return "> " + strings.Replace(s, "\n", "\n> ", -1)
+		},
+	}
+	return &markdownRenderer{
+		site: s,
+		tmpl: template.Must(template.New("docs").Funcs(funcs).Parse(markdownTemplates)),
+	}
+}
+
+func (r *markdownRenderer) renderIndex(buf *bytes.Buffer) error {
+	return r.tmpl.ExecuteTemplate(buf, "index", r.site)
+}
+
+func (r *markdownRenderer) renderType(buf *bytes.Buffer, t *typeDoc) error {
+	return r.tmpl.ExecuteTemplate(buf, "type", struct {
+		Site *site
+		Type *typeDoc
+	}{r.site, t})
+}
+
+func (r *markdownRenderer) assets(searchIndex []byte) []File {
+	return nil
+}
+
+const markdownTemplates = `
+{{- define "description"}}{{with .Description}}
+{{.}}
+{{end}}{{with deprecation .Directives}}
+{{quote (printf "**Deprecated:** %s" .)}}
+{{end}}{{end}}
+
+{{- define "arguments"}}
+{{range .}}- ` + "`{{.Name}}`" + `: {{typeRef .Type}}{{with .DefaultValue}} = ` + "`{{value .}}`" + `{{end}}
+{{- with .Description}} - {{.}}{{end}}{{with deprecation .Directives}} (**Deprecated:** {{.}}){{end}}
+{{end}}{{end}}
+
+{{- define "index"}}# {{.Title}}
+{{with .Roots}}
+## Operations
+{{range .}}
+- {{.Operation}}: {{link .Type}}
+{{- end}}
+{{end}}{{range kinds}}
+## {{.Label}}
+{{range .Types}}
+- {{link .Name}}
+{{- end}}
+{{end}}{{with .Directives}}
+## Directives
+{{range .}}
+### {{.Name}}
+
+` + "`@{{.Name}}`" + ` on {{range $i, $l := .Locations}}{{if $i}}, {{end}}{{$l}}{{end}}
+{{with .Description}}
+{{.}}
+{{end}}{{with .Arguments}}{{template "arguments" .}}{{end}}{{end}}{{end}}{{end}}
+
+{{- define "type"}}[{{.Site.Title}}]({{indexPage}})
+{{with .Type}}
+# {{.Name}}
+
+*{{kind .Kind}}*
+{{with .Description}}
+{{.}}
+{{end}}{{with .Interfaces}}
+## Implements
+{{range .}}
+- {{link .}}
+{{- end}}
+{{end}}{{with .Fields}}
+## Fields
+{{range .}}
+### {{.Name}}
+
+` + "`{{.Name}}`" + `: {{typeRef .Type}}{{with .DefaultValue}} = ` + "`{{value .}}`" + `{{end}}
+{{template "description" .}}{{with .Arguments}}
+Arguments:
+{{template "arguments" .}}{{end}}{{end}}{{end}}{{with .EnumValues}}
+## Values
+{{range .}}
+### {{.Name}}
+{{template "description" .}}{{end}}{{end}}{{with .Types}}
+## Possible types
+{{range .}}
+- {{link .}}
+{{- end}}
+{{end}}{{with .ImplementedBy}}
+## Implemented by
+{{range .}}
+- {{link .}}
+{{- end}}
+{{end}}{{with .UsedBy}}
+## Used by
+{{range .}}
+- [{{.Path}}]({{page .Type}}#{{anchor .Field}})
+{{- end}}
+{{end}}{{end}}{{end}}
+`
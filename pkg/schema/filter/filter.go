@@ -0,0 +1,485 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/mije/graphql-tools/pkg/schema/prune"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Options select the items of the derived schema.
+type Options struct {
+
+	// ExcludeDirectives removes types, fields, arguments and enum values marked by any of the directives
+	ExcludeDirectives []string
+
+	// IncludeTags keeps only types and fields tagged by @tag(name: "...") with any of the tags,
+	// fields of a tagged type are kept as well, root operation types are kept as long as any of their fields is
+	IncludeTags []string
+}
+
+const tagDirective = "tag"
+
+var builtInScalars = map[string]bool{"Int": true, "Float": true, "String": true, "Boolean": true, "ID": true}
+
+// Document derives a schema containing only the selected items.
+// References to the removed items are cleaned up: fields, arguments and input fields of removed types
+// are removed, and so are types left empty and types which became unreachable, see prune.Document. Interface fields
+// removed from any of the implementations are removed from the interface as well. Default values and arguments
+// of applied directives referring to removed enum values or input fields are rewritten without them, or removed.
+// Applications and definitions of the filtering directives are removed too. The given document is left intact.
+func Document(doc *ast.SchemaDocument, opts Options) *ast.SchemaDocument {
+	merged, _ := merge.Documents(doc)
+
+	f := &filter{
+		excluded: make(map[string]bool),
+		tags:     make(map[string]bool),
+		roots:    make(map[string]bool),
+	}
+	for _, d := range opts.ExcludeDirectives {
+		f.excluded[strings.TrimPrefix(d, "@")] = true
+	}
+	for _, t := range opts.IncludeTags {
+		f.tags[t] = true
+	}
+	for _, name := range rootTypes(merged) {
+		f.roots[name] = true
+	}
+
+	out := &ast.SchemaDocument{Position: merged.Position}
+	for _, def := range merged.Schema {
+		cp := *def
+		cp.Directives = f.stripDirectives(def.Directives)
+		out.Schema = append(out.Schema, &cp)
+	}
+	for _, d := range merged.Directives {
+		if !f.excluded[d.Name] && !(len(f.tags) > 0 && d.Name == tagDirective) {
+			cp := *d
+			out.Directives = append(out.Directives, &cp)
+		}
+	}
+	for _, def := range merged.Definitions {
+		if cp := f.definition(def); cp != nil {
+			out.Definitions = append(out.Definitions, cp)
+		}
+	}
+
+	for cleanUp(out) {
+	}
+	cleanUpValues(out)
+
+	pruned, _ := prune.Document(out)
+	return pruned
+}
+
+type filter struct {
+	excluded map[string]bool
+	tags     map[string]bool
+	roots    map[string]bool
+}
+
+// definition returns a copy of the definition with the selected items only, or nil if the type is removed.
+func (f *filter) definition(def *ast.Definition) *ast.Definition {
+	if f.isExcluded(def.Directives) {
+		return nil
+	}
+	included := f.isIncluded(def.Directives)
+
+	cp := *def
+	cp.Directives = f.stripDirectives(def.Directives)
+	cp.Fields, cp.EnumValues = nil, nil
+	for _, field := range def.Fields {
+		if f.isExcluded(field.Directives) || !included && !f.isIncluded(field.Directives) {
+			continue
+		}
+		fc := *field
+		fc.Directives = f.stripDirectives(field.Directives)
+		fc.Arguments = nil
+		for _, a := range field.Arguments {
+			if f.isExcluded(a.Directives) {
+				continue
+			}
+			ac := *a
+			ac.Directives = f.stripDirectives(a.Directives)
+			fc.Arguments = append(fc.Arguments, &ac)
+		}
+		cp.Fields = append(cp.Fields, &fc)
+	}
+	for _, v := range def.EnumValues {
+		if f.isExcluded(v.Directives) {
+			continue
+		}
+		vc := *v
+		vc.Directives = f.stripDirectives(v.Directives)
+		cp.EnumValues = append(cp.EnumValues, &vc)
+	}
+
+	// untagged types are kept only for the sake of their tagged fields
+	if !included && !f.roots[def.Name] && len(cp.Fields) == 0 {
+		return nil
+	}
+	return &cp
+}
+
+func (f *filter) isExcluded(list ast.DirectiveList) bool {
+	for _, d := range list {
+		if f.excluded[d.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filter) isIncluded(list ast.DirectiveList) bool {
+	if len(f.tags) == 0 {
+		return true
+	}
+	for _, d := range list {
+		if d.Name != tagDirective {
+			continue
+		}
+		if name := d.Arguments.ForName("name"); name != nil && f.tags[name.Value.Raw] {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filter) stripDirectives(list ast.DirectiveList) ast.DirectiveList {
+	var stripped ast.DirectiveList
+	for _, d := range list {
+		if !f.excluded[d.Name] && !(len(f.tags) > 0 && d.Name == tagDirective) {
+			stripped = append(stripped, d)
+		}
+	}
+	return stripped
+}
+
+// cleanUp removes a layer of references to missing types and reports whether anything was removed.
+func cleanUp(doc *ast.SchemaDocument) bool {
+	types := make(map[string]bool)
+	for name := range builtInScalars {
+		types[name] = true
+	}
+	for _, def := range doc.Definitions {
+		types[def.Name] = true
+	}
+	changed := false
+
+	var schema ast.SchemaDefinitionList
+	for _, def := range doc.Schema {
+		var ops ast.OperationTypeDefinitionList
+		for _, op := range def.OperationTypes {
+			if types[op.Type] {
+				ops = append(ops, op)
+			}
+		}
+		changed = changed || len(ops) != len(def.OperationTypes)
+		def.OperationTypes = ops
+		if len(ops) > 0 {
+			schema = append(schema, def)
+		}
+	}
+	doc.Schema = schema
+
+	var directives ast.DirectiveDefinitionList
+	removedDirectives := make(map[string]bool)
+	for _, d := range doc.Directives {
+		args, ok := cleanUpArguments(d.Arguments, types)
+		if !ok {
+			removedDirectives[d.Name] = true
+			continue
+		}
+		changed = changed || len(args) != len(d.Arguments)
+		d.Arguments = args
+		directives = append(directives, d)
+	}
+	doc.Directives = directives
+	changed = changed || len(removedDirectives) > 0
+
+	var defs ast.DefinitionList
+	for _, def := range doc.Definitions {
+		var fields ast.FieldList
+		for _, f := range def.Fields {
+			if !types[f.Type.Name()] {
+				continue
+			}
+			args, ok := cleanUpArguments(f.Arguments, types)
+			if !ok {
+				continue
+			}
+			changed = changed || len(args) != len(f.Arguments)
+			f.Arguments = args
+			f.Directives = removeDirectives(f.Directives, removedDirectives)
+			fields = append(fields, f)
+		}
+		changed = changed || len(fields) != len(def.Fields)
+		def.Fields = fields
+
+		n := len(def.Interfaces) + len(def.Types)
+		def.Interfaces = existing(def.Interfaces, types)
+		def.Types = existing(def.Types, types)
+		changed = changed || n != len(def.Interfaces)+len(def.Types)
+		def.Directives = removeDirectives(def.Directives, removedDirectives)
+		for _, v := range def.EnumValues {
+			v.Directives = removeDirectives(v.Directives, removedDirectives)
+		}
+
+		switch {
+		case (def.Kind == ast.Object || def.Kind == ast.Interface || def.Kind == ast.InputObject) && len(def.Fields) == 0,
+			def.Kind == ast.Union && len(def.Types) == 0,
+			def.Kind == ast.Enum && len(def.EnumValues) == 0:
+			changed = true
+		default:
+			defs = append(defs, def)
+		}
+	}
+	doc.Definitions = defs
+
+	return cleanUpInterfaces(doc) || changed
+}
+
+// cleanUpInterfaces removes the fields of interfaces which any implementation lacks, along with any of the arguments,
+// the implementation would not fulfill the contract of the interface otherwise.
+func cleanUpInterfaces(doc *ast.SchemaDocument) bool {
+	implementations := make(map[string][]*ast.Definition)
+	for _, def := range doc.Definitions {
+		for _, name := range def.Interfaces {
+			implementations[name] = append(implementations[name], def)
+		}
+	}
+
+	changed := false
+	for _, def := range doc.Definitions {
+		if def.Kind != ast.Interface {
+			continue
+		}
+		var fields ast.FieldList
+		for _, f := range def.Fields {
+			if implemented(f, implementations[def.Name]) {
+				fields = append(fields, f)
+			}
+		}
+		changed = changed || len(fields) != len(def.Fields)
+		def.Fields = fields
+	}
+	return changed
+}
+
+func implemented(f *ast.FieldDefinition, implementations []*ast.Definition) bool {
+	for _, impl := range implementations {
+		implField := impl.Fields.ForName(f.Name)
+		if implField == nil {
+			return false
+		}
+		for _, a := range f.Arguments {
+			if implField.Arguments.ForName(a.Name) == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// cleanUpArguments removes arguments of missing types, it fails if any of them is required.
+func cleanUpArguments(args ast.ArgumentDefinitionList, types map[string]bool) (ast.ArgumentDefinitionList, bool) {
+	var kept ast.ArgumentDefinitionList
+	for _, a := range args {
+		if types[a.Type.Name()] {
+			kept = append(kept, a)
+		} else if a.Type.NonNull && a.DefaultValue == nil {
+			return nil, false
+		}
+	}
+	return kept, true
+}
+
+// cleanUpValues removes the enum values and input fields missing in the document from the default values
+// and arguments of applied directives. Lists and input objects are rewritten without the missing parts,
+// values which cannot be rewritten are removed, and so are directive applications missing a required argument.
+func cleanUpValues(doc *ast.SchemaDocument) {
+	c := &valueCleaner{
+		types:      make(map[string]*ast.Definition),
+		directives: make(map[string]*ast.DirectiveDefinition),
+	}
+	for _, def := range doc.Definitions {
+		c.types[def.Name] = def
+	}
+	for _, d := range doc.Directives {
+		c.directives[d.Name] = d
+	}
+
+	for _, def := range doc.Schema {
+		def.Directives = c.cleanDirectives(def.Directives)
+	}
+	for _, d := range doc.Directives {
+		d.Arguments = c.cleanArguments(d.Arguments)
+	}
+	for _, def := range doc.Definitions {
+		def.Directives = c.cleanDirectives(def.Directives)
+		for _, f := range def.Fields {
+			f.DefaultValue = c.cleanValue(f.DefaultValue, f.Type)
+			f.Arguments = c.cleanArguments(f.Arguments)
+			f.Directives = c.cleanDirectives(f.Directives)
+		}
+		for _, v := range def.EnumValues {
+			v.Directives = c.cleanDirectives(v.Directives)
+		}
+	}
+}
+
+// valueCleaner copies the items it changes, they may be shared with the given document.
+type valueCleaner struct {
+	types      map[string]*ast.Definition
+	directives map[string]*ast.DirectiveDefinition
+}
+
+func (c *valueCleaner) cleanArguments(args ast.ArgumentDefinitionList) ast.ArgumentDefinitionList {
+	var cleaned ast.ArgumentDefinitionList
+	for _, a := range args {
+		if v := c.cleanValue(a.DefaultValue, a.Type); v != a.DefaultValue {
+			cp := *a
+			cp.DefaultValue = v
+			a = &cp
+		}
+		cleaned = append(cleaned, a)
+	}
+	return cleaned
+}
+
+func (c *valueCleaner) cleanDirectives(list ast.DirectiveList) ast.DirectiveList {
+	var cleaned ast.DirectiveList
+	for _, d := range list {
+		def := c.directives[d.Name]
+		if def == nil {
+			cleaned = append(cleaned, d)
+			continue
+		}
+		cp := *d
+		cp.Arguments = nil
+		for _, a := range d.Arguments {
+			var v *ast.Value
+			argDef := def.Arguments.ForName(a.Name)
+			if argDef != nil {
+				v = c.cleanValue(a.Value, argDef.Type)
+			}
+			if v == a.Value {
+				cp.Arguments = append(cp.Arguments, a)
+			} else if v != nil {
+				ac := *a
+				ac.Value = v
+				cp.Arguments = append(cp.Arguments, &ac)
+			}
+		}
+		if missingArgument(def.Arguments, cp.Arguments) {
+			continue
+		}
+		cleaned = append(cleaned, &cp)
+	}
+	return cleaned
+}
+
+func missingArgument(defs ast.ArgumentDefinitionList, args ast.ArgumentList) bool {
+	for _, d := range defs {
+		if d.Type.NonNull && d.DefaultValue == nil && args.ForName(d.Name) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanValue returns the value itself if it is valid, its rewritten copy, or nil if it cannot be rewritten.
+func (c *valueCleaner) cleanValue(v *ast.Value, t *ast.Type) *ast.Value {
+	if v == nil || v.Kind == ast.Variable || v.Kind == ast.NullValue {
+		return v
+	}
+
+	if t.Elem != nil {
+		if v.Kind != ast.ListValue {
+			return c.cleanValue(v, t.Elem)
+		}
+		var children ast.ChildValueList
+		for _, child := range v.Children {
+			if cv := c.cleanValue(child.Value, t.Elem); cv != nil {
+				children = append(children, &ast.ChildValue{Name: child.Name, Value: cv, Position: child.Position})
+			}
+		}
+		return withChildren(v, children)
+	}
+
+	def := c.types[t.NamedType]
+	switch {
+	case def == nil:
+		return v
+	case def.Kind == ast.Enum:
+		if v.Kind == ast.EnumValue && def.EnumValues.ForName(v.Raw) == nil {
+			return nil
+		}
+	case def.Kind == ast.InputObject && v.Kind == ast.ObjectValue:
+		var children ast.ChildValueList
+		for _, child := range v.Children {
+			f := def.Fields.ForName(child.Name)
+			if f == nil {
+				continue
+			}
+			cv := c.cleanValue(child.Value, f.Type)
+			if cv == nil {
+				if f.Type.NonNull && f.DefaultValue == nil {
+					return nil
+				}
+				continue
+			}
+			children = append(children, &ast.ChildValue{Name: child.Name, Value: cv, Position: child.Position})
+		}
+		return withChildren(v, children)
+	}
+	return v
+}
+
+// withChildren returns the value if the children are the same, or its copy with the children.
+func withChildren(v *ast.Value, children ast.ChildValueList) *ast.Value {
+	same := len(children) == len(v.Children)
+	for i := 0; same && i < len(children); i++ {
+		same = children[i].Name == v.Children[i].Name && children[i].Value == v.Children[i].Value
+	}
+	if same {
+		return v
+	}
+	cp := *v
+	cp.Children = children
+	return &cp
+}
+
+func removeDirectives(list ast.DirectiveList, names map[string]bool) ast.DirectiveList {
+	var kept ast.DirectiveList
+	for _, d := range list {
+		if !names[d.Name] {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+func existing(names []string, types map[string]bool) []string {
+	var kept []string
+	for _, name := range names {
+		if types[name] {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+func rootTypes(doc *ast.SchemaDocument) []string {
+	var names []string
+	for _, def := range doc.Schema {
+		for _, op := range def.OperationTypes {
+			names = append(names, op.Type)
+		}
+	}
+	if len(doc.Schema) == 0 {
+		names = append(names, "Query", "Mutation", "Subscription")
+	}
+	return names
+}
@@ -0,0 +1,186 @@
+package filter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/validate"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestDocument(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema string
+		opts   Options
+		want   string
+	}{
+		{
+			name: "Items marked by excluded directives are removed",
+			schema: `
+directive @internal on OBJECT | FIELD_DEFINITION | ARGUMENT_DEFINITION | ENUM_VALUE
+type Query {
+  hero(id: ID, debug: Boolean @internal): Hero
+  audit: AuditLog
+  stats: Stats @internal
+}
+type Hero { name: String episode: Episode secret: String @internal }
+enum Episode { NEW_HOPE TEST @internal }
+type AuditLog @internal { entries: [String] }
+type Stats { count: Int }
+`,
+			opts: Options{ExcludeDirectives: []string{"@internal"}},
+			want: `type Query {
+  hero(id: ID): Hero
+}
+
+type Hero {
+  name: String
+  episode: Episode
+}
+
+enum Episode {
+  NEW_HOPE
+}
+`,
+		},
+		{
+			name: "Dangling references are cleaned up",
+			schema: `
+directive @internal on OBJECT | INPUT_OBJECT
+type Query {
+  search(filter: Filter!): [Result]
+  heroes(filter: Filter): [Hero]
+  admin: Admin
+}
+union Result = Hero | Admin
+interface Node { id: ID! }
+type Hero implements Node & Secret { id: ID! }
+type Admin @internal { id: ID! }
+interface Secret @internal { id: ID! }
+input Filter @internal { name: String }
+`,
+			opts: Options{ExcludeDirectives: []string{"internal"}},
+			want: `type Query {
+  heroes: [Hero]
+}
+
+interface Node {
+  id: ID!
+}
+
+type Hero implements Node {
+  id: ID!
+}
+`,
+		},
+		{
+			name: "Only tagged items are included",
+			schema: `
+directive @tag(name: String!) on OBJECT | FIELD_DEFINITION
+type Query {
+  hero: Hero @tag(name: "public")
+  droid: Droid
+  villain: Villain @tag(name: "partner")
+}
+type Hero @tag(name: "public") { name: String friends: [Hero] }
+type Droid { name: String @tag(name: "public") model: String }
+type Villain @tag(name: "partner") { name: String }
+`,
+			opts: Options{IncludeTags: []string{"public"}},
+			want: `type Query {
+  hero: Hero
+}
+
+type Hero {
+  name: String
+  friends: [Hero]
+}
+`,
+		},
+		{
+			name: "Interface fields excluded from any implementation are removed from the interface",
+			schema: `
+directive @internal on FIELD_DEFINITION | ARGUMENT_DEFINITION
+type Query { node: Node user: User }
+interface Node { id: ID! secret: String search(debug: Boolean): [Node] }
+type User implements Node { id: ID! secret: String @internal search(debug: Boolean @internal): [Node] }
+type Group implements Node { id: ID! secret: String search(debug: Boolean): [Node] }
+`,
+			opts: Options{ExcludeDirectives: []string{"internal"}},
+			want: `type Query {
+  node: Node
+  user: User
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  search: [Node]
+}
+
+type Group implements Node {
+  id: ID!
+  secret: String
+  search(debug: Boolean): [Node]
+}
+`,
+		},
+		{
+			name: "Values referring to removed items are cleaned up",
+			schema: `
+directive @internal on FIELD_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
+directive @cached(episodes: [Episode!], episode: Episode!) on FIELD_DEFINITION
+type Query {
+  hero(episode: Episode = SECRET): String @cached(episode: SECRET)
+  heroes(episodes: [Episode!] = [NEW_HOPE, SECRET], filter: Filter = {name: "a", secret: 2}): [String] @cached(episodes: [SECRET], episode: NEW_HOPE)
+}
+enum Episode { NEW_HOPE SECRET @internal }
+input Filter { name: String secret: Int @internal }
+`,
+			opts: Options{ExcludeDirectives: []string{"internal"}},
+			want: `directive @cached(episodes: [Episode!], episode: Episode!) on FIELD_DEFINITION
+
+type Query {
+  hero(episode: Episode): String
+  heroes(episodes: [Episode!] = [NEW_HOPE], filter: Filter = {name: "a"}): [String] @cached(episodes: [], episode: NEW_HOPE)
+}
+
+enum Episode {
+  NEW_HOPE
+}
+
+input Filter {
+  name: String
+}
+`,
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			doc, err := parser.ParseSchema(&ast.Source{Input: s.schema})
+			if err != nil {
+				t.Fatalf("unable to parse schema: %v", err)
+			}
+
+			filtered := Document(doc, s.opts)
+			for _, e := range validate.Document(filtered) {
+				t.Errorf("invalid filtered schema: %v", e)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Document(&buf, filtered, format.Options{}); err != nil {
+				t.Fatalf("unable to print schema: %v", err)
+			}
+			if s.want != buf.String() {
+				t.Errorf("invalid schema:\nwant:\n%s\nhave:\n%s", s.want, buf.String())
+			}
+		})
+	}
+}
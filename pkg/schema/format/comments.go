@@ -0,0 +1,195 @@
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// The lexer drops comments, so they are scanned from the sources and attached to the nearest node printed
+// on its own line: a comment following code on the same line trails the preceding node, any other comment
+// leads the following one. Comments after the last node are printed at the end of the document.
+type comment struct {
+	start      int // offset in runes, like ast.Position.Start
+	text       string
+	trailing   bool
+	blankAfter bool
+}
+
+type comments struct {
+	leading  map[*ast.Position][]comment
+	trailing map[*ast.Position][]comment
+	dangling []comment
+}
+
+func (p *printer) leading(pos *ast.Position, prefix string) {
+	for _, c := range p.comments.leading[pos] {
+		p.comment(c, prefix)
+	}
+}
+
+func (p *printer) comment(c comment, prefix string) {
+	p.buf.WriteString(prefix + "#" + c.text + "\n")
+	if c.blankAfter {
+		p.buf.WriteString("\n")
+	}
+}
+
+func (p *printer) trailing(pos *ast.Position) string {
+	var s string
+	for _, c := range p.comments.trailing[pos] {
+		s += " #" + c.text
+	}
+	return s
+}
+
+func attachComments(nodes []*ast.Position) *comments {
+	cs := &comments{
+		leading:  make(map[*ast.Position][]comment),
+		trailing: make(map[*ast.Position][]comment),
+	}
+
+	var sources []*ast.Source
+	bySource := make(map[*ast.Source][]*ast.Position)
+	for _, pos := range nodes {
+		if pos == nil || pos.Src == nil || pos.Src.BuiltIn {
+			continue
+		}
+		if _, ok := bySource[pos.Src]; !ok {
+			sources = append(sources, pos.Src)
+		}
+		bySource[pos.Src] = append(bySource[pos.Src], pos)
+	}
+
+	for _, src := range sources {
+		nodes := bySource[src]
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return nodes[i].Start < nodes[j].Start
+		})
+
+		for _, c := range scanComments(src.Input) {
+			i := sort.Search(len(nodes), func(i int) bool {
+				return nodes[i].Start > c.start
+			})
+			switch {
+			case c.trailing && i > 0:
+				c.blankAfter = false
+				cs.trailing[nodes[i-1]] = append(cs.trailing[nodes[i-1]], c)
+			case i < len(nodes):
+				cs.leading[nodes[i]] = append(cs.leading[nodes[i]], c)
+			default:
+				cs.dangling = append(cs.dangling, c)
+			}
+		}
+	}
+
+	if n := len(cs.dangling); n > 0 {
+		cs.dangling[n-1].blankAfter = false
+	}
+	return cs
+}
+
+// lineNodes returns positions of the nodes printed on their own lines.
+func lineNodes(doc *ast.SchemaDocument) []*ast.Position {
+	var nodes []*ast.Position
+	arguments := func(args ast.ArgumentDefinitionList) {
+		if multiline(args) {
+			for _, a := range args {
+				nodes = append(nodes, a.Position)
+			}
+		}
+	}
+
+	for _, list := range []ast.SchemaDefinitionList{doc.Schema, doc.SchemaExtension} {
+		for _, def := range list {
+			nodes = append(nodes, def.Position)
+			for _, op := range def.OperationTypes {
+				nodes = append(nodes, op.Position)
+			}
+		}
+	}
+	for _, def := range doc.Directives {
+		nodes = append(nodes, def.Position)
+		arguments(def.Arguments)
+	}
+	for _, list := range []ast.DefinitionList{doc.Definitions, doc.Extensions} {
+		for _, def := range list {
+			nodes = append(nodes, def.Position)
+			for _, f := range def.Fields {
+				nodes = append(nodes, f.Position)
+				arguments(f.Arguments)
+			}
+			for _, v := range def.EnumValues {
+				nodes = append(nodes, v.Position)
+			}
+		}
+	}
+	return nodes
+}
+
+// scanComments returns the comments of the source, skipping '#' within string values.
+func scanComments(src string) []comment {
+	var (
+		input    = []rune(src)
+		lines    = strings.Split(strings.Replace(src, "\r\n", "\n", -1), "\n")
+		line     = 1
+		code     bool
+		comments []comment
+	)
+
+	for i := 0; i < len(input); i++ {
+		switch r := input[i]; {
+		case r == '\n':
+			line++
+			code = false
+		case r == ' ' || r == '\t' || r == '\r' || r == ',' || r == '\uFEFF':
+		case r == '#':
+			j := i + 1
+			for j < len(input) && input[j] != '\n' && input[j] != '\r' {
+				j++
+			}
+			comments = append(comments, comment{
+				start:      i,
+				text:       strings.TrimRight(string(input[i+1:j]), " \t"),
+				trailing:   code,
+				blankAfter: line < len(lines) && strings.TrimSpace(lines[line]) == "",
+			})
+			i = j - 1
+		case r == '"' && hasPrefix(input[i:], `"""`):
+			code = true
+			for i += 3; i < len(input) && !hasPrefix(input[i:], `"""`); i++ {
+				if hasPrefix(input[i:], `\"""`) {
+					i += 3
+				} else if input[i] == '\n' {
+					line++
+				}
+			}
+			i += 2
+		case r == '"':
+			code = true
+			for i++; i < len(input) && input[i] != '"' && input[i] != '\n'; i++ {
+				if input[i] == '\\' {
+					i++
+				}
+			}
+		default:
+			code = true
+		}
+	}
+
+	return comments
+}
+
+func hasPrefix(input []rune, prefix string) bool {
+	p := []rune(prefix)
+	if len(input) < len(p) {
+		return false
+	}
+	for i := range p {
+		if input[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+// Options control how schemas are printed.
+type Options struct {
+
+	// Sort orders directives, types, fields and arguments alphabetically instead of keeping the source order
+	Sort bool
+
+	// StripComments drops the comments of the source instead of preserving them
+	StripComments bool
+}
+
+// Schema re-prints a GraphQL schema encoded using SDL in the canonical form.
+func Schema(name string, r io.Reader, opts Options) ([]byte, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read schema '%s': %v", name, err)
+	}
+
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Name: name, Input: string(b)})
+	if gqlErr != nil {
+		return nil, fmt.Errorf("unable to parse schema '%s': %v", name, gqlErr)
+	}
+
+	var buf bytes.Buffer
+	if err := Document(&buf, doc, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Document prints an already parsed schema as SDL.
+// Comments are preserved as long as the document positions refer to its source.
+func Document(w io.Writer, doc *ast.SchemaDocument, opts Options) error {
+	p := &printer{
+		opts:     opts,
+		comments: attachComments(nil),
+	}
+	if !opts.StripComments {
+		p.comments = attachComments(lineNodes(doc))
+	}
+
+	for i, it := range p.items(doc) {
+		if i > 0 {
+			p.buf.WriteString("\n")
+		}
+		it.print()
+	}
+
+	if len(p.comments.dangling) > 0 {
+		if p.buf.Len() > 0 {
+			p.buf.WriteString("\n")
+		}
+		for _, c := range p.comments.dangling {
+			p.comment(c, "")
+		}
+	}
+
+	_, err := w.Write(p.buf.Bytes())
+	return err
+}
+
+// Value prints a value literal, e.g. a default value, as SDL.
+func Value(v *ast.Value) string {
+	return value(v)
+}
+
+// item is a top-level definition of a document.
+type item struct {
+	group int // schema, schema extension, directive or type
+	name  string
+	ext   bool
+	pos   *ast.Position
+	print func()
+}
+
+func (p *printer) items(doc *ast.SchemaDocument) []item {
+	var items []item
+	for _, def := range doc.Schema {
+		def := def
+		items = append(items, item{group: 0, pos: def.Position, print: func() { p.schema(def, false) }})
+	}
+	for _, def := range doc.SchemaExtension {
+		def := def
+		items = append(items, item{group: 1, ext: true, pos: def.Position, print: func() { p.schema(def, true) }})
+	}
+	for _, def := range doc.Directives {
+		def := def
+		items = append(items, item{group: 2, name: def.Name, pos: def.Position, print: func() { p.directiveDefinition(def) }})
+	}
+	for _, def := range doc.Definitions {
+		def := def
+		items = append(items, item{group: 3, name: def.Name, pos: def.Position, print: func() { p.definition(def, false) }})
+	}
+	for _, def := range doc.Extensions {
+		def := def
+		items = append(items, item{group: 3, name: def.Name, ext: true, pos: def.Position, print: func() { p.definition(def, true) }})
+	}
+
+	if p.opts.Sort {
+		sort.SliceStable(items, func(i, j int) bool {
+			x, y := items[i], items[j]
+			if x.group != y.group {
+				return x.group < y.group
+			}
+			if x.name != y.name {
+				return x.name < y.name
+			}
+			return !x.ext && y.ext
+		})
+		return items
+	}
+
+	// keep the source order when all definitions come from sources, the order of their lists otherwise
+	sources := make(map[*ast.Source]int)
+	for _, it := range items {
+		if it.pos == nil || it.pos.Src == nil {
+			return items
+		}
+		if _, ok := sources[it.pos.Src]; !ok {
+			sources[it.pos.Src] = len(sources)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		x, y := items[i].pos, items[j].pos
+		if x.Src != y.Src {
+			return sources[x.Src] < sources[y.Src]
+		}
+		return x.Start < y.Start
+	})
+	return items
+}
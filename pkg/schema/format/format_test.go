@@ -0,0 +1,187 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema string
+		opts   Options
+		want   string
+	}{
+		{
+			name: "Definitions are printed in canonical form",
+			schema: `
+schema { query: Query mutation: Mutation }
+directive @auth(role: String = "user") on FIELD_DEFINITION|OBJECT
+"Root query"
+type Query implements Node&Entity @auth(role: "admin") { id: ID!, heroes(first: Int = 10, episodes: [Episode!] = [NEW_HOPE]): [Hero] @deprecated(reason: "Use \"characters\"") }
+type Mutation {
+  addHero(
+    "Hero to add"
+    input: HeroInput!
+  ): Hero
+}
+union SearchResult=Hero|Droid
+enum Episode{NEW_HOPE EMPIRE @deprecated}
+input HeroInput { name: String! = "R2" filter: Filter = {a: 1, b: [true]} }
+scalar Time @specifiedBy(url: "https://example.com")
+extend type Hero @key(fields: "id")
+`,
+			want: `schema {
+  query: Query
+  mutation: Mutation
+}
+
+directive @auth(role: String = "user") on FIELD_DEFINITION | OBJECT
+
+"""Root query"""
+type Query implements Node & Entity @auth(role: "admin") {
+  id: ID!
+  heroes(first: Int = 10, episodes: [Episode!] = [NEW_HOPE]): [Hero] @deprecated(reason: "Use \"characters\"")
+}
+
+type Mutation {
+  addHero(
+    """Hero to add"""
+    input: HeroInput!
+  ): Hero
+}
+
+union SearchResult = Hero | Droid
+
+enum Episode {
+  NEW_HOPE
+  EMPIRE @deprecated
+}
+
+input HeroInput {
+  name: String! = "R2"
+  filter: Filter = {a: 1, b: [true]}
+}
+
+scalar Time @specifiedBy(url: "https://example.com")
+
+extend type Hero @key(fields: "id")
+`,
+		},
+		{
+			name: "Descriptions are printed as block strings",
+			schema: `
+"""
+  Multi-line
+  description
+"""
+type A {
+  "Ends with \"quote\""
+  a: String
+  """Contains \""" quotes"""
+  b: String
+}
+`,
+			want: `"""
+Multi-line
+description
+"""
+type A {
+  """
+  Ends with "quote"
+  """
+  a: String
+  """Contains \""" quotes"""
+  b: String
+}
+`,
+		},
+		{
+			name: "Comments are preserved",
+			schema: `# Schema of the hero service
+
+# Hero
+type Hero { # all heroes
+  # Name of the hero
+  name: String # lint-disable-line field-description-required
+  "Not a # comment"
+  id: ID!
+}
+type Query { hero: Hero } # no comment
+# The end
+`,
+			want: `# Schema of the hero service
+
+# Hero
+type Hero { # all heroes
+  # Name of the hero
+  name: String # lint-disable-line field-description-required
+  """Not a # comment"""
+  id: ID!
+}
+
+type Query {
+  hero: Hero # no comment
+}
+
+# The end
+`,
+		},
+		{
+			name: "Definitions can be sorted",
+			schema: `
+extend type B { c: Int }
+type B { b(z: Int, a: Int): Int a: Int }
+# A
+type A { a: Int }
+directive @b on FIELD_DEFINITION
+directive @a on FIELD_DEFINITION
+schema { query: A }
+`,
+			opts: Options{Sort: true},
+			want: `schema {
+  query: A
+}
+
+directive @a on FIELD_DEFINITION
+
+directive @b on FIELD_DEFINITION
+
+# A
+type A {
+  a: Int
+}
+
+type B {
+  a: Int
+  b(a: Int, z: Int): Int
+}
+
+extend type B {
+  c: Int
+}
+`,
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			have, err := Schema("test.graphql", strings.NewReader(s.schema), s.opts)
+			if err != nil {
+				t.Fatalf("unable to format schema: %v", err)
+			}
+			if s.want != string(have) {
+				t.Errorf("invalid schema:\nwant:\n%s\nhave:\n%s", s.want, have)
+			}
+
+			again, err := Schema("test.graphql", bytes.NewReader(have), s.opts)
+			if err != nil {
+				t.Fatalf("unable to format formatted schema: %v", err)
+			}
+			if !bytes.Equal(have, again) {
+				t.Errorf("formatting is not stable:\nfirst:\n%s\nsecond:\n%s", have, again)
+			}
+		})
+	}
+}
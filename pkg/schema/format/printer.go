@@ -0,0 +1,280 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+const indent = "  "
+
+type printer struct {
+	buf      bytes.Buffer
+	opts     Options
+	comments *comments
+}
+
+func (p *printer) schema(def *ast.SchemaDefinition, extend bool) {
+	p.leading(def.Position, "")
+	p.description(def.Description, "")
+
+	if extend {
+		p.buf.WriteString("extend ")
+	}
+	p.buf.WriteString("schema" + directives(def.Directives))
+	if len(def.OperationTypes) == 0 {
+		p.buf.WriteString(p.trailing(def.Position) + "\n")
+		return
+	}
+
+	p.buf.WriteString(" {" + p.trailing(def.Position) + "\n")
+	for _, op := range def.OperationTypes {
+		p.leading(op.Position, indent)
+		p.buf.WriteString(fmt.Sprintf("%s%s: %s%s\n", indent, op.Operation, op.Type, p.trailing(op.Position)))
+	}
+	p.buf.WriteString("}\n")
+}
+
+func (p *printer) directiveDefinition(def *ast.DirectiveDefinition) {
+	p.leading(def.Position, "")
+	p.description(def.Description, "")
+
+	p.buf.WriteString("directive @" + def.Name)
+	trailing := p.arguments(def.Position, def.Arguments, "")
+
+	locations := make([]string, len(def.Locations))
+	for i, l := range def.Locations {
+		locations[i] = string(l)
+	}
+	p.buf.WriteString(" on " + strings.Join(locations, " | ") + trailing + "\n")
+}
+
+var definitionKeywords = map[ast.DefinitionKind]string{
+	ast.Scalar:      "scalar",
+	ast.Object:      "type",
+	ast.Interface:   "interface",
+	ast.Union:       "union",
+	ast.Enum:        "enum",
+	ast.InputObject: "input",
+}
+
+func (p *printer) definition(def *ast.Definition, extend bool) {
+	p.leading(def.Position, "")
+	p.description(def.Description, "")
+
+	if extend {
+		p.buf.WriteString("extend ")
+	}
+	p.buf.WriteString(definitionKeywords[def.Kind] + " " + def.Name)
+	if len(def.Interfaces) > 0 {
+		p.buf.WriteString(" implements " + strings.Join(def.Interfaces, " & "))
+	}
+	p.buf.WriteString(directives(def.Directives))
+
+	switch def.Kind {
+	case ast.Scalar:
+		p.buf.WriteString(p.trailing(def.Position) + "\n")
+	case ast.Union:
+		if len(def.Types) > 0 {
+			p.buf.WriteString(" = " + strings.Join(def.Types, " | "))
+		}
+		p.buf.WriteString(p.trailing(def.Position) + "\n")
+	case ast.Enum:
+		if len(def.EnumValues) == 0 {
+			p.buf.WriteString(p.trailing(def.Position) + "\n")
+			return
+		}
+		p.buf.WriteString(" {" + p.trailing(def.Position) + "\n")
+		for _, v := range def.EnumValues {
+			p.leading(v.Position, indent)
+			p.description(v.Description, indent)
+			p.buf.WriteString(indent + v.Name + directives(v.Directives) + p.trailing(v.Position) + "\n")
+		}
+		p.buf.WriteString("}\n")
+	default:
+		if len(def.Fields) == 0 {
+			p.buf.WriteString(p.trailing(def.Position) + "\n")
+			return
+		}
+		p.buf.WriteString(" {" + p.trailing(def.Position) + "\n")
+		for _, f := range p.sortFields(def.Fields) {
+			p.field(f)
+		}
+		p.buf.WriteString("}\n")
+	}
+}
+
+func (p *printer) field(f *ast.FieldDefinition) {
+	p.leading(f.Position, indent)
+	p.description(f.Description, indent)
+
+	p.buf.WriteString(indent + f.Name)
+	trailing := p.arguments(f.Position, f.Arguments, indent)
+	p.buf.WriteString(": " + f.Type.String())
+	if f.DefaultValue != nil {
+		p.buf.WriteString(" = " + value(f.DefaultValue))
+	}
+	p.buf.WriteString(directives(f.Directives) + trailing + "\n")
+}
+
+// arguments prints the argument definitions of a field or directive, one per line when any of them has a description.
+// It returns the trailing comment of the parent which is left to be printed at the end of its line.
+func (p *printer) arguments(parent *ast.Position, args ast.ArgumentDefinitionList, prefix string) string {
+	if len(args) == 0 {
+		return p.trailing(parent)
+	}
+	args = p.sortArguments(args)
+
+	if !multiline(args) {
+		list := make([]string, len(args))
+		for i, a := range args {
+			list[i] = argument(a)
+		}
+		p.buf.WriteString("(" + strings.Join(list, ", ") + ")")
+		return p.trailing(parent)
+	}
+
+	p.buf.WriteString("(" + p.trailing(parent) + "\n")
+	for _, a := range args {
+		p.leading(a.Position, prefix+indent)
+		p.description(a.Description, prefix+indent)
+		p.buf.WriteString(prefix + indent + argument(a) + p.trailing(a.Position) + "\n")
+	}
+	p.buf.WriteString(prefix + ")")
+	return ""
+}
+
+func multiline(args ast.ArgumentDefinitionList) bool {
+	for _, a := range args {
+		if a.Description != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func argument(a *ast.ArgumentDefinition) string {
+	s := a.Name + ": " + a.Type.String()
+	if a.DefaultValue != nil {
+		s += " = " + value(a.DefaultValue)
+	}
+	return s + directives(a.Directives)
+}
+
+func (p *printer) sortFields(fields ast.FieldList) ast.FieldList {
+	if !p.opts.Sort {
+		return fields
+	}
+	sorted := append(ast.FieldList(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func (p *printer) sortArguments(args ast.ArgumentDefinitionList) ast.ArgumentDefinitionList {
+	if !p.opts.Sort {
+		return args
+	}
+	sorted := append(ast.ArgumentDefinitionList(nil), args...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// description prints the description as a block string, on a single line unless it spans more lines.
+func (p *printer) description(s, prefix string) {
+	if s == "" {
+		return
+	}
+
+	s = strings.Replace(s, `"""`, `\"""`, -1)
+	if !strings.Contains(s, "\n") && !strings.HasSuffix(s, `"`) && !strings.HasSuffix(s, `\`) {
+		p.buf.WriteString(prefix + `"""` + s + `"""` + "\n")
+		return
+	}
+
+	p.buf.WriteString(prefix + `"""` + "\n")
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			p.buf.WriteString(prefix + line)
+		}
+		p.buf.WriteString("\n")
+	}
+	p.buf.WriteString(prefix + `"""` + "\n")
+}
+
+func directives(list ast.DirectiveList) string {
+	var s string
+	for _, d := range list {
+		s += " @" + d.Name
+		if len(d.Arguments) == 0 {
+			continue
+		}
+		args := make([]string, len(d.Arguments))
+		for i, a := range d.Arguments {
+			args[i] = a.Name + ": " + value(a.Value)
+		}
+		s += "(" + strings.Join(args, ", ") + ")"
+	}
+	return s
+}
+
+func value(v *ast.Value) string {
+	switch v.Kind {
+	case ast.Variable:
+		return "$" + v.Raw
+	case ast.StringValue, ast.BlockValue:
+		return quote(v.Raw)
+	case ast.ListValue:
+		list := make([]string, len(v.Children))
+		for i, c := range v.Children {
+			list[i] = value(c.Value)
+		}
+		return "[" + strings.Join(list, ", ") + "]"
+	case ast.ObjectValue:
+		list := make([]string, len(v.Children))
+		for i, c := range v.Children {
+			list[i] = c.Name + ": " + value(c.Value)
+		}
+		return "{" + strings.Join(list, ", ") + "}"
+	default:
+		return v.Raw
+	}
+}
+
+// quote encodes the string as a GraphQL string value.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,307 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Format of the rendered graph.
+type Format string
+
+const (
+	// DOT is the Graphviz language
+	DOT = Format("dot")
+
+	// Mermaid is the flowchart syntax of Mermaid
+	Mermaid = Format("mermaid")
+)
+
+// Options select the rendered part of the schema.
+type Options struct {
+
+	// Format of the graph, DOT unless set
+	Format Format
+
+	// Root limits the graph to the types reachable from the type, see Depth
+	Root string
+
+	// Depth limits the graph to the types reachable by the given number of edges from the root
+	// (from the root operation types unless Root is set), zero means no limit
+	Depth int
+
+	// Highlight marks the given types, see Changed
+	Highlight []string
+}
+
+// Edge kinds, field edges also stand for the references by arguments of the fields.
+const (
+	fieldEdge      = "field"
+	implementsEdge = "implements"
+	memberEdge     = "member"
+)
+
+type node struct {
+	def         *ast.Definition
+	highlighted bool
+}
+
+type edge struct {
+	from, to string
+	kind     string
+	labels   []string
+}
+
+type graph struct {
+	nodes []*node
+	edges []*edge
+}
+
+// Write renders the types as nodes and their fields, implemented interfaces and union members as edges.
+// Extensions are applied, built-in scalars are left out.
+func Write(w io.Writer, doc *ast.SchemaDocument, opts Options) error {
+	if opts.Format == "" {
+		opts.Format = DOT
+	}
+	if opts.Format != DOT && opts.Format != Mermaid {
+		return fmt.Errorf("unsupported format '%s'", opts.Format)
+	}
+
+	doc, _ = merge.Documents(doc)
+	types := make(map[string]*ast.Definition)
+	for _, def := range doc.Definitions {
+		types[def.Name] = def
+	}
+	if opts.Root != "" && types[opts.Root] == nil {
+		return fmt.Errorf("type '%s' not found", opts.Root)
+	}
+
+	included := selectTypes(doc, types, opts)
+	highlighted := make(map[string]bool)
+	for _, name := range opts.Highlight {
+		highlighted[name] = true
+	}
+
+	g := &graph{}
+	index := make(map[string]*edge)
+	addEdge := func(from, to, kind, label string) {
+		if !included[to] {
+			return
+		}
+		key := strings.Join([]string{from, to, kind}, " ")
+		e, ok := index[key]
+		if !ok {
+			e = &edge{from: from, to: to, kind: kind}
+			index[key] = e
+			g.edges = append(g.edges, e)
+		}
+		if label != "" {
+			e.labels = append(e.labels, label)
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		if !included[def.Name] {
+			continue
+		}
+		g.nodes = append(g.nodes, &node{def: def, highlighted: highlighted[def.Name]})
+		for _, f := range def.Fields {
+			addEdge(def.Name, f.Type.Name(), fieldEdge, f.Name)
+			for _, a := range f.Arguments {
+				addEdge(def.Name, a.Type.Name(), fieldEdge, fmt.Sprintf("%s(%s)", f.Name, a.Name))
+			}
+		}
+		for _, name := range def.Interfaces {
+			addEdge(def.Name, name, implementsEdge, "")
+		}
+		for _, name := range def.Types {
+			addEdge(def.Name, name, memberEdge, "")
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	if opts.Format == Mermaid {
+		writeMermaid(bw, g)
+	} else {
+		writeDOT(bw, g)
+	}
+	return bw.Flush()
+}
+
+// selectTypes returns the types within the depth from the roots. Implementations of an interface
+// are selected along with it as they may be returned in its place.
+func selectTypes(doc *ast.SchemaDocument, types map[string]*ast.Definition, opts Options) map[string]bool {
+	included := make(map[string]bool)
+	if opts.Root == "" && opts.Depth == 0 {
+		for name := range types {
+			included[name] = true
+		}
+		return included
+	}
+
+	implementations := make(map[string][]string)
+	for _, def := range doc.Definitions {
+		for _, name := range def.Interfaces {
+			implementations[name] = append(implementations[name], def.Name)
+		}
+	}
+
+	var roots []string
+	if opts.Root != "" {
+		roots = []string{opts.Root}
+	} else {
+		for _, def := range doc.Schema {
+			for _, op := range def.OperationTypes {
+				roots = append(roots, op.Type)
+			}
+		}
+		if len(doc.Schema) == 0 {
+			roots = []string{"Query", "Mutation", "Subscription"}
+		}
+	}
+
+	depth := make(map[string]int)
+	var queue []string
+	visit := func(name string, d int) {
+		if _, ok := types[name]; ok && !included[name] {
+			included[name] = true
+			depth[name] = d
+			queue = append(queue, name)
+		}
+	}
+	for _, name := range roots {
+		visit(name, 0)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, impl := range implementations[name] {
+			visit(impl, depth[name])
+		}
+		if opts.Depth > 0 && depth[name] >= opts.Depth {
+			continue
+		}
+		def := types[name]
+		for _, f := range def.Fields {
+			visit(f.Type.Name(), depth[name]+1)
+			for _, a := range f.Arguments {
+				visit(a.Type.Name(), depth[name]+1)
+			}
+		}
+		for _, i := range def.Interfaces {
+			visit(i, depth[name]+1)
+		}
+		for _, t := range def.Types {
+			visit(t, depth[name]+1)
+		}
+	}
+	return included
+}
+
+// Changed returns names of the types touched by the changes, sorted. As the names are taken from the paths
+// of the changes, names of changed directives are returned as well, they match no type though.
+func Changed(res *compare.Result) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, c := range res.Changes() {
+		name := strings.SplitN(c.Path, ".", 2)[0]
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var dotShapes = map[ast.DefinitionKind]string{
+	ast.Object:      `shape=box`,
+	ast.Interface:   `shape=box, style=rounded`,
+	ast.Union:       `shape=hexagon`,
+	ast.Enum:        `shape=folder`,
+	ast.InputObject: `shape=parallelogram`,
+	ast.Scalar:      `shape=ellipse`,
+}
+
+var dotEdges = map[string]string{
+	implementsEdge: `style=dashed, arrowhead=empty`,
+	memberEdge:     `style=dotted`,
+}
+
+func writeDOT(w *bufio.Writer, g *graph) {
+	fmt.Fprintln(w, "digraph schema {")
+	for _, n := range g.nodes {
+		attrs := dotShapes[n.def.Kind]
+		if n.highlighted {
+			if strings.Contains(attrs, "style=") {
+				attrs = strings.Replace(attrs, "style=", `style="filled,`, 1) + `"`
+			} else {
+				attrs += ", style=filled"
+			}
+			attrs += `, fillcolor="#ffd966"`
+		}
+		fmt.Fprintf(w, "  %q [%s];\n", n.def.Name, attrs)
+	}
+	for _, e := range g.edges {
+		var attrs []string
+		if len(e.labels) > 0 {
+			attrs = append(attrs, fmt.Sprintf("label=%q", strings.Join(e.labels, ", ")))
+		}
+		if a, ok := dotEdges[e.kind]; ok {
+			attrs = append(attrs, a)
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(w, "  %q -> %q [%s];\n", e.from, e.to, strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(w, "  %q -> %q;\n", e.from, e.to)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+var mermaidShapes = map[ast.DefinitionKind][2]string{
+	ast.Object:      {"[", "]"},
+	ast.Interface:   {"([", "])"},
+	ast.Union:       {"{{", "}}"},
+	ast.Enum:        {"[[", "]]"},
+	ast.InputObject: {"[/", "/]"},
+	ast.Scalar:      {"((", "))"},
+}
+
+func writeMermaid(w *bufio.Writer, g *graph) {
+	fmt.Fprintln(w, "graph LR")
+	var highlighted []string
+	for _, n := range g.nodes {
+		shape := mermaidShapes[n.def.Kind]
+		fmt.Fprintf(w, "  %s%s%s%s\n", n.def.Name, shape[0], n.def.Name, shape[1])
+		if n.highlighted {
+			highlighted = append(highlighted, n.def.Name)
+		}
+	}
+	for _, e := range g.edges {
+		arrow := "-->"
+		if e.kind != fieldEdge {
+			arrow = "-.->"
+		}
+		label := strings.Join(e.labels, ", ")
+		if e.kind == implementsEdge {
+			label = implementsEdge
+		}
+		if label != "" {
+			fmt.Fprintf(w, "  %s %s|%q| %s\n", e.from, arrow, label, e.to)
+		} else {
+			fmt.Fprintf(w, "  %s %s %s\n", e.from, arrow, e.to)
+		}
+	}
+	if len(highlighted) > 0 {
+		fmt.Fprintln(w, "  classDef changed fill:#ffd966")
+		fmt.Fprintf(w, "  class %s changed\n", strings.Join(highlighted, ","))
+	}
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const schema = `
+type Query { hero(episode: Episode): Character search: SearchResult }
+interface Character { name: String friends: [Character] }
+type Human implements Character { name: String friends: [Character] starship: Starship }
+type Starship { name: String }
+union SearchResult = Human
+enum Episode { NEW_HOPE }
+`
+
+func TestWrite(t *testing.T) {
+	testData := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "All types in DOT",
+			want: `digraph schema {
+  "Query" [shape=box];
+  "Character" [shape=box, style=rounded];
+  "Human" [shape=box];
+  "Starship" [shape=box];
+  "SearchResult" [shape=hexagon];
+  "Episode" [shape=folder];
+  "Query" -> "Character" [label="hero"];
+  "Query" -> "Episode" [label="hero(episode)"];
+  "Query" -> "SearchResult" [label="search"];
+  "Character" -> "Character" [label="friends"];
+  "Human" -> "Character" [label="friends"];
+  "Human" -> "Starship" [label="starship"];
+  "Human" -> "Character" [style=dashed, arrowhead=empty];
+  "SearchResult" -> "Human" [style=dotted];
+}
+`,
+		},
+		{
+			name: "Root, depth and highlight in Mermaid",
+			opts: Options{Format: Mermaid, Root: "Character", Depth: 1, Highlight: []string{"Human"}},
+			want: `graph LR
+  Character([Character])
+  Human[Human]
+  Starship[Starship]
+  Character -->|"friends"| Character
+  Human -->|"friends"| Character
+  Human -->|"starship"| Starship
+  Human -.->|"implements"| Character
+  classDef changed fill:#ffd966
+  class Human changed
+`,
+		},
+		{
+			name: "Depth from the root operation types",
+			opts: Options{Depth: 1},
+			want: `digraph schema {
+  "Query" [shape=box];
+  "Character" [shape=box, style=rounded];
+  "Human" [shape=box];
+  "SearchResult" [shape=hexagon];
+  "Episode" [shape=folder];
+  "Query" -> "Character" [label="hero"];
+  "Query" -> "Episode" [label="hero(episode)"];
+  "Query" -> "SearchResult" [label="search"];
+  "Character" -> "Character" [label="friends"];
+  "Human" -> "Character" [label="friends"];
+  "Human" -> "Character" [style=dashed, arrowhead=empty];
+  "SearchResult" -> "Human" [style=dotted];
+}
+`,
+		},
+	}
+
+	doc, err := parser.ParseSchema(&ast.Source{Input: schema})
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, doc, s.opts); err != nil {
+				t.Fatalf("unable to write graph: %v", err)
+			}
+			if have := buf.String(); s.want != have {
+				t.Errorf("invalid graph:\nwant:\n%s\nhave:\n%s", s.want, have)
+			}
+		})
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	doc, err := parser.ParseSchema(&ast.Source{Input: schema})
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+	if err := Write(&bytes.Buffer{}, doc, Options{Format: "svg"}); err == nil {
+		t.Error("unsupported format must fail")
+	}
+	if err := Write(&bytes.Buffer{}, doc, Options{Root: "Villain"}); err == nil {
+		t.Error("unknown root type must fail")
+	}
+}
+
+func TestChanged(t *testing.T) {
+	res, err := compare.Schema(
+		bytes.NewBufferString(schema),
+		bytes.NewBufferString(strings.NewReplacer(
+			"type Starship { name: String }", "type Starship { name: String length: Float }",
+			"enum Episode { NEW_HOPE }", "enum Episode { NEW_HOPE EMPIRE }",
+		).Replace(schema)),
+	)
+	if err != nil {
+		t.Fatalf("unable to compare schemas: %v", err)
+	}
+	if have, want := Changed(res), []string{"Episode", "Starship"}; !reflect.DeepEqual(want, have) {
+		t.Errorf("invalid changed types: want %q, have %q", want, have)
+	}
+}
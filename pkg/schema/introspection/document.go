@@ -0,0 +1,266 @@
+package introspection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+var (
+	builtInScalars    = []string{"String", "Int", "Float", "Boolean", "ID"}
+	builtInDirectives = []string{"skip", "include", "deprecated", "specifiedBy"}
+)
+
+// IsBuiltInType reports whether the type is defined by the specification and thus not part of SDL.
+func IsBuiltInType(name string) bool {
+	return strings.HasPrefix(name, "__") || contains(builtInScalars, name)
+}
+
+// IsBuiltInDirective reports whether the directive is defined by the specification and thus not part of SDL.
+func IsBuiltInDirective(name string) bool {
+	return contains(builtInDirectives, name)
+}
+
+// Document converts the introspection into a schema document.
+// Built-in types and directives are omitted, deprecations are turned into @deprecated directives.
+func (s *Schema) Document() (*ast.SchemaDocument, error) {
+	doc := new(ast.SchemaDocument)
+
+	schemaDef := new(ast.SchemaDefinition)
+	for _, root := range []struct {
+		op   ast.Operation
+		typ  *TypeName
+		dflt string
+	}{
+		{ast.Query, s.QueryType, "Query"},
+		{ast.Mutation, s.MutationType, "Mutation"},
+		{ast.Subscription, s.SubscriptionType, "Subscription"},
+	} {
+		if root.typ == nil {
+			continue
+		}
+		schemaDef.OperationTypes = append(schemaDef.OperationTypes, &ast.OperationTypeDefinition{
+			Operation: root.op,
+			Type:      root.typ.Name,
+		})
+		if root.typ.Name != root.dflt {
+			doc.Schema = ast.SchemaDefinitionList{schemaDef}
+		}
+	}
+
+	for _, d := range s.Directives {
+		if IsBuiltInDirective(d.Name) {
+			continue
+		}
+		def := &ast.DirectiveDefinition{
+			Description: str(d.Description),
+			Name:        d.Name,
+		}
+		for _, l := range d.Locations {
+			def.Locations = append(def.Locations, ast.DirectiveLocation(l))
+		}
+		args, err := argumentDefinitions(d.Args)
+		if err != nil {
+			return nil, fmt.Errorf("directive '%s': %v", d.Name, err)
+		}
+		def.Arguments = args
+		doc.Directives = append(doc.Directives, def)
+	}
+
+	for _, t := range s.Types {
+		if IsBuiltInType(t.Name) {
+			continue
+		}
+		def, err := definition(t)
+		if err != nil {
+			return nil, fmt.Errorf("type '%s': %v", t.Name, err)
+		}
+		doc.Definitions = append(doc.Definitions, def)
+	}
+
+	return doc, nil
+}
+
+func definition(t Type) (*ast.Definition, error) {
+	def := &ast.Definition{
+		Description: str(t.Description),
+		Name:        t.Name,
+	}
+
+	switch t.Kind {
+	case Scalar:
+		def.Kind = ast.Scalar
+		if t.SpecifiedByURL != nil {
+			def.Directives = append(def.Directives, &ast.Directive{
+				Name:      "specifiedBy",
+				Arguments: ast.ArgumentList{stringArgument("url", *t.SpecifiedByURL)},
+				Location:  ast.LocationScalar,
+			})
+		}
+	case Object, Interface:
+		def.Kind = ast.Object
+		if t.Kind == Interface {
+			def.Kind = ast.Interface
+		}
+		for _, i := range t.Interfaces {
+			def.Interfaces = append(def.Interfaces, str(i.Name))
+		}
+		for _, f := range t.Fields {
+			args, err := argumentDefinitions(f.Args)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s': %v", f.Name, err)
+			}
+			typ, err := typeRef(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s': %v", f.Name, err)
+			}
+			def.Fields = append(def.Fields, &ast.FieldDefinition{
+				Description: str(f.Description),
+				Name:        f.Name,
+				Arguments:   args,
+				Type:        typ,
+				Directives:  deprecation(f.IsDeprecated, f.DeprecationReason, ast.LocationFieldDefinition),
+			})
+		}
+	case Union:
+		def.Kind = ast.Union
+		for _, p := range t.PossibleTypes {
+			def.Types = append(def.Types, str(p.Name))
+		}
+	case Enum:
+		def.Kind = ast.Enum
+		for _, v := range t.EnumValues {
+			def.EnumValues = append(def.EnumValues, &ast.EnumValueDefinition{
+				Description: str(v.Description),
+				Name:        v.Name,
+				Directives:  deprecation(v.IsDeprecated, v.DeprecationReason, ast.LocationEnumValue),
+			})
+		}
+	case InputObject:
+		def.Kind = ast.InputObject
+		for _, f := range t.InputFields {
+			val, err := value(f.DefaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("input field '%s': %v", f.Name, err)
+			}
+			typ, err := typeRef(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("input field '%s': %v", f.Name, err)
+			}
+			def.Fields = append(def.Fields, &ast.FieldDefinition{
+				Description:  str(f.Description),
+				Name:         f.Name,
+				DefaultValue: val,
+				Type:         typ,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind '%s'", t.Kind)
+	}
+
+	return def, nil
+}
+
+func argumentDefinitions(values []InputValue) (ast.ArgumentDefinitionList, error) {
+	var args ast.ArgumentDefinitionList
+	for _, v := range values {
+		val, err := value(v.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("argument '%s': %v", v.Name, err)
+		}
+		typ, err := typeRef(v.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument '%s': %v", v.Name, err)
+		}
+		args = append(args, &ast.ArgumentDefinition{
+			Description:  str(v.Description),
+			Name:         v.Name,
+			DefaultValue: val,
+			Type:         typ,
+		})
+	}
+	return args, nil
+}
+
+// typeRef converts a type reference, wrapping types must wrap another type and named types must have a name.
+func typeRef(t TypeRef) (*ast.Type, error) {
+	switch t.Kind {
+	case NonNull, List:
+		if t.OfType == nil {
+			return nil, fmt.Errorf("type reference of kind '%s' without ofType", t.Kind)
+		}
+		typ, err := typeRef(*t.OfType)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == List {
+			return ast.ListType(typ, nil), nil
+		}
+		if typ.NonNull {
+			return nil, fmt.Errorf("type reference of kind '%s' wrapping another one", t.Kind)
+		}
+		typ.NonNull = true
+		return typ, nil
+	default:
+		if str(t.Name) == "" {
+			return nil, fmt.Errorf("type reference of kind '%s' without name", t.Kind)
+		}
+		return ast.NamedType(str(t.Name), nil), nil
+	}
+}
+
+func deprecation(deprecated bool, reason *string, loc ast.DirectiveLocation) ast.DirectiveList {
+	if !deprecated {
+		return nil
+	}
+	d := &ast.Directive{
+		Name:     "deprecated",
+		Location: loc,
+	}
+	if reason != nil && *reason != defaultDeprecationReason {
+		d.Arguments = ast.ArgumentList{stringArgument("reason", *reason)}
+	}
+	return ast.DirectiveList{d}
+}
+
+func stringArgument(name, s string) *ast.Argument {
+	return &ast.Argument{
+		Name: name,
+		Value: &ast.Value{
+			Kind: ast.StringValue,
+			Raw:  s,
+		},
+	}
+}
+
+// value parses a default value literal, the parser exposes values only as part of a document.
+func value(literal *string) (*ast.Value, error) {
+	if literal == nil {
+		return nil, nil
+	}
+	doc, err := parser.ParseSchema(&ast.Source{
+		Input: fmt.Sprintf("input Value { value: Value = %s }", *literal),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid default value '%s': %v", *literal, err)
+	}
+	return doc.Definitions[0].Fields[0].DefaultValue, nil
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
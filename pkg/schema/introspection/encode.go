@@ -0,0 +1,350 @@
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+var kinds = map[ast.DefinitionKind]TypeKind{
+	ast.Scalar:      Scalar,
+	ast.Object:      Object,
+	ast.Interface:   Interface,
+	ast.Union:       Union,
+	ast.Enum:        Enum,
+	ast.InputObject: InputObject,
+}
+
+const defaultDeprecationReason = "No longer supported"
+
+// FromDocument converts the schema document into the result of the introspection query a server
+// of the schema would return. Extensions are applied, the built-in scalars, directives and introspection types
+// are added and ordered the way the reference implementation does. All fields and enum values are included,
+// deprecated ones too.
+func FromDocument(doc *ast.SchemaDocument) (*Schema, error) {
+	doc, _ = merge.Documents(doc)
+
+	e := &encoder{
+		types:           make(map[string]*ast.Definition),
+		implementations: make(map[string][]string),
+		seen:            make(map[string]bool),
+		pending:         make(map[string]bool),
+	}
+	for _, def := range builtIn().Definitions {
+		e.types[def.Name] = def
+	}
+	for _, def := range doc.Definitions {
+		e.types[def.Name] = def
+		if def.Kind == ast.Object {
+			for _, name := range def.Interfaces {
+				e.implementations[name] = append(e.implementations[name], def.Name)
+			}
+		}
+	}
+
+	s := &Schema{
+		Types:      []Type{},
+		Directives: []Directive{},
+	}
+	for _, op := range rootOperations(doc) {
+		if e.types[op.Type] == nil {
+			return nil, fmt.Errorf("%s type '%s' not found", op.Operation, op.Type)
+		}
+		switch op.Operation {
+		case ast.Query:
+			s.QueryType = &TypeName{Name: op.Type}
+		case ast.Mutation:
+			s.MutationType = &TypeName{Name: op.Type}
+		case ast.Subscription:
+			s.SubscriptionType = &TypeName{Name: op.Type}
+		}
+	}
+
+	directives := append(ast.DirectiveDefinitionList{}, doc.Directives...)
+	for _, d := range builtIn().Directives {
+		if doc.Directives.ForName(d.Name) == nil {
+			directives = append(directives, d)
+		}
+	}
+
+	// the defined types are listed in the source order, each followed by the built-in types it refers to
+	// unless listed already, then come the types referred to by the directives and the introspection types
+	for _, def := range doc.Definitions {
+		e.pending[def.Name] = true
+	}
+	for _, def := range doc.Definitions {
+		delete(e.pending, def.Name)
+		e.collect(def.Name)
+	}
+	for _, op := range rootOperations(doc) {
+		e.collect(op.Type)
+	}
+	for _, d := range directives {
+		for _, a := range d.Arguments {
+			e.collect(a.Type.Name())
+		}
+	}
+	e.collect("__Schema")
+	if e.missing != "" {
+		return nil, fmt.Errorf("type '%s' not found", e.missing)
+	}
+
+	for _, name := range e.order {
+		t, err := e.typ(e.types[name])
+		if err != nil {
+			return nil, fmt.Errorf("type '%s': %v", name, err)
+		}
+		s.Types = append(s.Types, t)
+	}
+	for _, d := range directives {
+		dir := Directive{
+			Name:        d.Name,
+			Description: optional(d.Description),
+			Locations:   []string{},
+		}
+		for _, l := range d.Locations {
+			dir.Locations = append(dir.Locations, string(l))
+		}
+		args, err := e.inputValues(d.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("directive '%s': %v", d.Name, err)
+		}
+		dir.Args = args
+		s.Directives = append(s.Directives, dir)
+	}
+	return s, nil
+}
+
+// Marshal encodes the schema as the response to the introspection query, see Unmarshal.
+func Marshal(s *Schema) ([]byte, error) {
+	var v struct {
+		Data struct {
+			Schema *Schema `json:"__schema"`
+		} `json:"data"`
+	}
+	v.Data.Schema = s
+	return json.MarshalIndent(v, "", "  ")
+}
+
+type encoder struct {
+	types           map[string]*ast.Definition
+	implementations map[string][]string
+	seen            map[string]bool
+	pending         map[string]bool
+	order           []string
+	missing         string
+}
+
+// collect lists the type and the types it refers to, depth first, the pending types are left for later.
+func (e *encoder) collect(name string) {
+	if e.seen[name] || e.pending[name] {
+		return
+	}
+	def, ok := e.types[name]
+	if !ok {
+		if e.missing == "" {
+			e.missing = name
+		}
+		return
+	}
+	e.seen[name] = true
+	e.order = append(e.order, name)
+
+	for _, t := range def.Types {
+		e.collect(t)
+	}
+	for _, i := range def.Interfaces {
+		e.collect(i)
+	}
+	for _, f := range def.Fields {
+		e.collect(f.Type.Name())
+		for _, a := range f.Arguments {
+			e.collect(a.Type.Name())
+		}
+	}
+}
+
+func (e *encoder) typ(def *ast.Definition) (Type, error) {
+	t := Type{
+		Kind:        kinds[def.Kind],
+		Name:        def.Name,
+		Description: optional(def.Description),
+	}
+
+	switch def.Kind {
+	case ast.Scalar:
+		if d := def.Directives.ForName("specifiedBy"); d != nil {
+			if url := d.Arguments.ForName("url"); url != nil && url.Value != nil {
+				t.SpecifiedByURL = optional(url.Value.Raw)
+			}
+		}
+	case ast.Object, ast.Interface:
+		t.Fields, t.Interfaces = []Field{}, []TypeRef{}
+		for _, f := range def.Fields {
+			args, err := e.inputValues(f.Arguments)
+			if err != nil {
+				return t, fmt.Errorf("field '%s': %v", f.Name, err)
+			}
+			ref, err := e.typeRef(f.Type)
+			if err != nil {
+				return t, fmt.Errorf("field '%s': %v", f.Name, err)
+			}
+			deprecated, reason := deprecationReason(f.Directives)
+			t.Fields = append(t.Fields, Field{
+				Name:              f.Name,
+				Description:       optional(f.Description),
+				Args:              args,
+				Type:              ref,
+				IsDeprecated:      deprecated,
+				DeprecationReason: reason,
+			})
+		}
+		for _, name := range def.Interfaces {
+			ref, err := e.namedTypeRef(name)
+			if err != nil {
+				return t, err
+			}
+			t.Interfaces = append(t.Interfaces, ref)
+		}
+		if def.Kind == ast.Interface {
+			t.PossibleTypes = []TypeRef{}
+			for _, name := range e.implementations[def.Name] {
+				ref, err := e.namedTypeRef(name)
+				if err != nil {
+					return t, err
+				}
+				t.PossibleTypes = append(t.PossibleTypes, ref)
+			}
+		}
+	case ast.Union:
+		t.PossibleTypes = []TypeRef{}
+		for _, name := range def.Types {
+			ref, err := e.namedTypeRef(name)
+			if err != nil {
+				return t, err
+			}
+			t.PossibleTypes = append(t.PossibleTypes, ref)
+		}
+	case ast.Enum:
+		t.EnumValues = []EnumValue{}
+		for _, v := range def.EnumValues {
+			deprecated, reason := deprecationReason(v.Directives)
+			t.EnumValues = append(t.EnumValues, EnumValue{
+				Name:              v.Name,
+				Description:       optional(v.Description),
+				IsDeprecated:      deprecated,
+				DeprecationReason: reason,
+			})
+		}
+	case ast.InputObject:
+		t.InputFields = []InputValue{}
+		for _, f := range def.Fields {
+			v, err := e.inputValue(f.Name, f.Description, f.Type, f.DefaultValue)
+			if err != nil {
+				return t, fmt.Errorf("input field '%s': %v", f.Name, err)
+			}
+			t.InputFields = append(t.InputFields, v)
+		}
+	}
+	return t, nil
+}
+
+func (e *encoder) inputValues(args ast.ArgumentDefinitionList) ([]InputValue, error) {
+	values := []InputValue{}
+	for _, a := range args {
+		v, err := e.inputValue(a.Name, a.Description, a.Type, a.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("argument '%s': %v", a.Name, err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func (e *encoder) inputValue(name, description string, typ *ast.Type, dflt *ast.Value) (InputValue, error) {
+	ref, err := e.typeRef(typ)
+	if err != nil {
+		return InputValue{}, err
+	}
+	v := InputValue{
+		Name:        name,
+		Description: optional(description),
+		Type:        ref,
+	}
+	if dflt != nil {
+		s := format.Value(dflt)
+		v.DefaultValue = &s
+	}
+	return v, nil
+}
+
+func (e *encoder) typeRef(t *ast.Type) (TypeRef, error) {
+	if t.NonNull {
+		nullable := *t
+		nullable.NonNull = false
+		of, err := e.typeRef(&nullable)
+		if err != nil {
+			return TypeRef{}, err
+		}
+		return TypeRef{Kind: NonNull, OfType: &of}, nil
+	}
+	if t.Elem != nil {
+		of, err := e.typeRef(t.Elem)
+		if err != nil {
+			return TypeRef{}, err
+		}
+		return TypeRef{Kind: List, OfType: &of}, nil
+	}
+	return e.namedTypeRef(t.NamedType)
+}
+
+func (e *encoder) namedTypeRef(name string) (TypeRef, error) {
+	def, ok := e.types[name]
+	if !ok {
+		return TypeRef{}, fmt.Errorf("type '%s' not found", name)
+	}
+	return TypeRef{Kind: kinds[def.Kind], Name: &name}, nil
+}
+
+// rootOperations returns the root operation types, the default ones unless the schema definition says otherwise.
+func rootOperations(doc *ast.SchemaDocument) ast.OperationTypeDefinitionList {
+	var ops ast.OperationTypeDefinitionList
+	for _, def := range doc.Schema {
+		ops = append(ops, def.OperationTypes...)
+	}
+	if len(doc.Schema) > 0 {
+		return ops
+	}
+	for _, op := range []ast.Operation{ast.Query, ast.Mutation, ast.Subscription} {
+		name := strings.Title(string(op))
+		for _, def := range doc.Definitions {
+			if def.Name == name {
+				ops = append(ops, &ast.OperationTypeDefinition{Operation: op, Type: name})
+			}
+		}
+	}
+	return ops
+}
+
+func deprecationReason(list ast.DirectiveList) (bool, *string) {
+	d := list.ForName("deprecated")
+	if d == nil {
+		return false, nil
+	}
+	reason := defaultDeprecationReason
+	if r := d.Arguments.ForName("reason"); r != nil && r.Value != nil {
+		reason = r.Value.Raw
+	}
+	return true, &reason
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,160 @@
+package introspection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Query is the standard introspection query, it asks for everything Schema describes.
+var Query = query(true)
+
+func query(specifiedByURL bool) string {
+	scalar := ""
+	if specifiedByURL {
+		scalar = "\n  specifiedByURL"
+	}
+	return `query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description` + scalar + `
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+}
+
+// Fetch runs the introspection query against the GraphQL endpoint, the header is sent along with the request.
+// Servers implementing the specification prior to specifiedByURL reject the query, it is retried without it then.
+func Fetch(client *http.Client, endpoint string, header http.Header) (*Schema, error) {
+	s, err := fetch(client, endpoint, header, Query)
+	if err != nil && strings.Contains(err.Error(), "specifiedByURL") {
+		return fetch(client, endpoint, header, query(false))
+	}
+	return s, err
+}
+
+func fetch(client *http.Client, endpoint string, header http.Header, query string) (*Schema, error) {
+	body, err := json.Marshal(map[string]string{
+		"query":         query,
+		"operationName": "IntrospectionQuery",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch schema from '%s': %v", endpoint, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch schema from '%s': %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch schema from '%s': %v", endpoint, err)
+	}
+
+	var v struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(b, &v); err == nil && len(v.Errors) > 0 {
+		messages := make([]string, len(v.Errors))
+		for i, e := range v.Errors {
+			messages[i] = e.Message
+		}
+		return nil, fmt.Errorf("unable to fetch schema from '%s': %s", endpoint, strings.Join(messages, "; "))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch schema from '%s': unexpected status '%s'", endpoint, resp.Status)
+	}
+
+	s, err := Unmarshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch schema from '%s': %v", endpoint, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,122 @@
+package introspection
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// Schema is the result of the introspection query, see https://spec.graphql.org/June2018/#sec-Schema-Introspection.
+type Schema struct {
+	QueryType        *TypeName   `json:"queryType"`
+	MutationType     *TypeName   `json:"mutationType"`
+	SubscriptionType *TypeName   `json:"subscriptionType"`
+	Types            []Type      `json:"types"`
+	Directives       []Directive `json:"directives"`
+}
+
+// TypeName references a named type.
+type TypeName struct {
+	Name string `json:"name"`
+}
+
+// TypeKind is a kind of type.
+type TypeKind string
+
+const (
+	Scalar      = TypeKind("SCALAR")
+	Object      = TypeKind("OBJECT")
+	Interface   = TypeKind("INTERFACE")
+	Union       = TypeKind("UNION")
+	Enum        = TypeKind("ENUM")
+	InputObject = TypeKind("INPUT_OBJECT")
+	List        = TypeKind("LIST")
+	NonNull     = TypeKind("NON_NULL")
+)
+
+// Type describes a named type.
+type Type struct {
+	Kind           TypeKind     `json:"kind"`
+	Name           string       `json:"name"`
+	Description    *string      `json:"description"`
+	SpecifiedByURL *string      `json:"specifiedByURL"`
+	Fields         []Field      `json:"fields"`
+	Interfaces     []TypeRef    `json:"interfaces"`
+	PossibleTypes  []TypeRef    `json:"possibleTypes"`
+	EnumValues     []EnumValue  `json:"enumValues"`
+	InputFields    []InputValue `json:"inputFields"`
+}
+
+// TypeRef references a named or a wrapping type.
+type TypeRef struct {
+	Kind   TypeKind `json:"kind"`
+	Name   *string  `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+// Field describes a field of an object or an interface.
+type Field struct {
+	Name              string       `json:"name"`
+	Description       *string      `json:"description"`
+	Args              []InputValue `json:"args"`
+	Type              TypeRef      `json:"type"`
+	IsDeprecated      bool         `json:"isDeprecated"`
+	DeprecationReason *string      `json:"deprecationReason"`
+}
+
+// InputValue describes an argument or an input field.
+type InputValue struct {
+	Name         string  `json:"name"`
+	Description  *string `json:"description"`
+	Type         TypeRef `json:"type"`
+	DefaultValue *string `json:"defaultValue"`
+}
+
+// EnumValue describes a value of an enum.
+type EnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+// Directive describes a directive.
+type Directive struct {
+	Name        string       `json:"name"`
+	Description *string      `json:"description"`
+	Locations   []string     `json:"locations"`
+	Args        []InputValue `json:"args"`
+}
+
+// Parse reads the introspection query result. Both the full response ({"data": {"__schema": ...}})
+// and the bare data ({"__schema": ...}) are accepted.
+func Parse(r io.Reader) (*Schema, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read introspection: %v", err)
+	}
+	return Unmarshal(b)
+}
+
+// Unmarshal decodes the introspection query result, see Parse.
+func Unmarshal(b []byte) (*Schema, error) {
+	var v struct {
+		Data *struct {
+			Schema *Schema `json:"__schema"`
+		} `json:"data"`
+		Schema *Schema `json:"__schema"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("unable to decode introspection: %v", err)
+	}
+
+	switch {
+	case v.Data != nil && v.Data.Schema != nil:
+		return v.Data.Schema, nil
+	case v.Schema != nil:
+		return v.Schema, nil
+	default:
+		return nil, fmt.Errorf("unable to decode introspection: missing __schema")
+	}
+}
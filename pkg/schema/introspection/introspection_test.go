@@ -0,0 +1,275 @@
+package introspection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const starwars = `{
+  "data": {
+    "__schema": {
+      "queryType": {"name": "Query"},
+      "mutationType": null,
+      "subscriptionType": null,
+      "types": [
+        {"kind": "SCALAR", "name": "String", "description": null, "fields": null, "interfaces": null, "possibleTypes": null, "enumValues": null, "inputFields": null},
+        {"kind": "OBJECT", "name": "Query", "description": null, "interfaces": [], "fields": [
+          {"name": "hero", "description": "The hero", "isDeprecated": false, "deprecationReason": null,
+           "args": [{"name": "episode", "description": null, "defaultValue": "JEDI", "type": {"kind": "ENUM", "name": "Episode", "ofType": null}}],
+           "type": {"kind": "INTERFACE", "name": "Character", "ofType": null}},
+          {"name": "search", "description": null, "isDeprecated": true, "deprecationReason": "Use hero", "args": [],
+           "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "LIST", "name": null, "ofType": {"kind": "UNION", "name": "SearchResult", "ofType": null}}}}
+        ]},
+        {"kind": "ENUM", "name": "Episode", "description": null, "enumValues": [
+          {"name": "NEWHOPE", "description": null, "isDeprecated": false, "deprecationReason": null},
+          {"name": "JEDI", "description": null, "isDeprecated": false, "deprecationReason": null}
+        ]},
+        {"kind": "INTERFACE", "name": "Character", "description": null, "interfaces": [], "fields": [
+          {"name": "name", "description": null, "isDeprecated": false, "deprecationReason": null, "args": [],
+           "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}}
+        ], "possibleTypes": [{"kind": "OBJECT", "name": "Droid", "ofType": null}]},
+        {"kind": "OBJECT", "name": "Droid", "description": null, "interfaces": [{"kind": "INTERFACE", "name": "Character", "ofType": null}], "fields": [
+          {"name": "name", "description": null, "isDeprecated": false, "deprecationReason": null, "args": [],
+           "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "String", "ofType": null}}}
+        ]},
+        {"kind": "UNION", "name": "SearchResult", "description": null, "possibleTypes": [{"kind": "OBJECT", "name": "Droid", "ofType": null}]},
+        {"kind": "INPUT_OBJECT", "name": "Filter", "description": null, "inputFields": [
+          {"name": "limit", "description": null, "defaultValue": "10", "type": {"kind": "SCALAR", "name": "Int", "ofType": null}}
+        ]},
+        {"kind": "OBJECT", "name": "__Schema", "description": null, "fields": []}
+      ],
+      "directives": [
+        {"name": "deprecated", "description": null, "locations": ["FIELD_DEFINITION", "ENUM_VALUE"], "args": []},
+        {"name": "auth", "description": null, "locations": ["FIELD_DEFINITION"], "args": [
+          {"name": "role", "description": null, "defaultValue": "\"admin\"", "type": {"kind": "SCALAR", "name": "String", "ofType": null}}
+        ]}
+      ]
+    }
+  }
+}`
+
+const starwarsSDL = `
+directive @auth(role: String = "admin") on FIELD_DEFINITION
+type Query {
+  "The hero"
+  hero(episode: Episode = JEDI): Character
+  search: [SearchResult]! @deprecated(reason: "Use hero")
+}
+enum Episode { NEWHOPE JEDI }
+interface Character { name: String! }
+type Droid implements Character { name: String! }
+union SearchResult = Droid
+input Filter { limit: Int = 10 }
+`
+
+func TestDocument(t *testing.T) {
+	s, err := Parse(strings.NewReader(starwars))
+	if err != nil {
+		t.Fatalf("unable to parse introspection: %v", err)
+	}
+	have, err := s.Document()
+	if err != nil {
+		t.Fatalf("unable to convert introspection: %v", err)
+	}
+	want, gqlErr := parser.ParseSchema(&ast.Source{Input: starwarsSDL})
+	if gqlErr != nil {
+		t.Fatalf("unable to parse schema: %v", gqlErr)
+	}
+
+	res, err := compare.Documents(want, have)
+	if err != nil {
+		t.Fatalf("unable to compare schemas: %v", err)
+	}
+	for _, c := range res.Changes() {
+		t.Errorf("unexpected change: %s", c.Message)
+	}
+	if l := len(have.Definitions); l != 6 {
+		t.Errorf("invalid number of definitions: want 6, have %d", l)
+	}
+	if d := have.Definitions.ForName("Query").Fields.ForName("search").Directives.ForName("deprecated"); d == nil {
+		t.Error("missing deprecation")
+	}
+}
+
+func TestDocumentErrors(t *testing.T) {
+	testData := []struct {
+		name string
+		typ  string
+		want string
+	}{
+		{
+			name: "non-null without ofType",
+			typ:  `{"kind": "NON_NULL", "ofType": null}`,
+			want: "type 'Query': field 'a': type reference of kind 'NON_NULL' without ofType",
+		},
+		{
+			name: "truncated chain",
+			typ:  `{"kind": "NON_NULL", "ofType": {"kind": "LIST", "ofType": {"kind": "NON_NULL", "ofType": null}}}`,
+			want: "type 'Query': field 'a': type reference of kind 'NON_NULL' without ofType",
+		},
+		{
+			name: "list without ofType",
+			typ:  `{"kind": "LIST"}`,
+			want: "type 'Query': field 'a': type reference of kind 'LIST' without ofType",
+		},
+		{
+			name: "named type without name",
+			typ:  `{"kind": "SCALAR", "name": null}`,
+			want: "type 'Query': field 'a': type reference of kind 'SCALAR' without name",
+		},
+	}
+
+	for _, td := range testData {
+		t.Run(td.name, func(t *testing.T) {
+			s, err := Unmarshal([]byte(`{"__schema": {"queryType": {"name": "Query"}, "types": [` +
+				`{"kind": "OBJECT", "name": "Query", "fields": [{"name": "a", "args": [], "type": ` + td.typ + `}]}]}}`))
+			if err != nil {
+				t.Fatalf("unable to parse introspection: %v", err)
+			}
+			if _, err := s.Document(); err == nil || err.Error() != td.want {
+				t.Errorf("got %v, want %s", err, td.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{`{}`, `{"data": {}}`, `[]`, `{`} {
+		if _, err := Unmarshal([]byte(s)); err == nil {
+			t.Errorf("introspection %q should be invalid", s)
+		}
+	}
+}
+
+func TestFromDocument(t *testing.T) {
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Input: starwarsSDL + `
+scalar Date @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+extend enum Episode { EMPIRE @deprecated }
+`})
+	if gqlErr != nil {
+		t.Fatalf("unable to parse schema: %v", gqlErr)
+	}
+	s, err := FromDocument(doc)
+	if err != nil {
+		t.Fatalf("unable to convert schema: %v", err)
+	}
+
+	var types []string
+	for _, typ := range s.Types {
+		types = append(types, typ.Name)
+	}
+	want := "Query Episode Character String Droid SearchResult Filter Int Date Boolean " +
+		"__Schema __Type __TypeKind __Field __InputValue __EnumValue __Directive __DirectiveLocation"
+	if have := strings.Join(types, " "); want != have {
+		t.Errorf("invalid types:\nwant %s\nhave %s", want, have)
+	}
+
+	var directives []string
+	for _, d := range s.Directives {
+		directives = append(directives, d.Name)
+	}
+	if have := strings.Join(directives, " "); have != "auth include skip deprecated specifiedBy" {
+		t.Errorf("invalid directives: %s", have)
+	}
+
+	b, err := Marshal(s)
+	if err != nil {
+		t.Fatalf("unable to encode introspection: %v", err)
+	}
+	for _, part := range []string{
+		`"queryType": {` + "\n" + `        "name": "Query"`,
+		`"mutationType": null`,
+		`"specifiedByURL": "https://tools.ietf.org/html/rfc3339"`,
+		`"defaultValue": "\"admin\""`,
+		`"deprecationReason": "No longer supported"`,
+		`"possibleTypes": [` + "\n" + `            {` + "\n" + `              "kind": "OBJECT",` + "\n" + `              "name": "Droid"`,
+	} {
+		if !strings.Contains(string(b), part) {
+			t.Errorf("introspection does not contain %s", part)
+		}
+	}
+
+	// the conversion is reversible
+	parsed, err := Unmarshal(b)
+	if err != nil {
+		t.Fatalf("unable to decode introspection: %v", err)
+	}
+	have, err := parsed.Document()
+	if err != nil {
+		t.Fatalf("unable to convert introspection: %v", err)
+	}
+	merged, _ := merge.Documents(doc)
+	res, err := compare.Documents(merged, have)
+	if err != nil {
+		t.Fatalf("unable to compare schemas: %v", err)
+	}
+	for _, c := range res.Changes() {
+		t.Errorf("unexpected change: %s", c.Message)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for _, sdl := range []string{
+		`type Query { hero: Hero }`,
+		`schema { query: Root } type Query { a: Int }`,
+	} {
+		doc, gqlErr := parser.ParseSchema(&ast.Source{Input: sdl})
+		if gqlErr != nil {
+			t.Fatalf("unable to parse schema: %v", gqlErr)
+		}
+		if _, err := FromDocument(doc); err == nil {
+			t.Errorf("schema %q should be invalid", sdl)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		queries = append(queries, req.Query)
+
+		// mimic a server predating specifiedByURL
+		if strings.Contains(req.Query, "specifiedByURL") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors": [{"message": "Cannot query field \"specifiedByURL\" on type \"__Type\"."}]}`))
+			return
+		}
+		w.Write([]byte(starwars))
+	}))
+	defer srv.Close()
+
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+	s, err := Fetch(srv.Client(), srv.URL, header)
+	if err != nil {
+		t.Fatalf("unable to fetch schema: %v", err)
+	}
+	if len(queries) != 2 || strings.Contains(queries[1], "specifiedByURL") {
+		t.Errorf("query must be retried without specifiedByURL: %d queries", len(queries))
+	}
+	if s.QueryType == nil || s.QueryType.Name != "Query" || len(s.Types) != 8 {
+		t.Errorf("invalid schema: %+v", s)
+	}
+
+	if _, err := Fetch(srv.Client(), srv.URL, nil); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("unauthorized request must fail: %v", err)
+	}
+}
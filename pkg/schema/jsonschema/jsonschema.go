@@ -0,0 +1,242 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Draft is the version of JSON Schema generated.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema, only the keywords needed to describe GraphQL types are supported.
+type Schema struct {
+	Schema               string        `json:"$schema,omitempty"`
+	Ref                  string        `json:"$ref,omitempty"`
+	Description          string        `json:"description,omitempty"`
+	Type                 interface{}   `json:"type,omitempty"`
+	Format               string        `json:"format,omitempty"`
+	Enum                 []interface{} `json:"enum,omitempty"`
+	Minimum              *float64      `json:"minimum,omitempty"`
+	Maximum              *float64      `json:"maximum,omitempty"`
+	Items                *Schema       `json:"items,omitempty"`
+	Properties           Properties    `json:"properties,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	AdditionalProperties *bool         `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema     `json:"anyOf,omitempty"`
+	Default              interface{}   `json:"default,omitempty"`
+	Deprecated           bool          `json:"deprecated,omitempty"`
+	Definitions          Properties    `json:"definitions,omitempty"`
+}
+
+// Properties are named schemas encoded as a JSON object in their order.
+type Properties []Property
+
+// Property is a named schema.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+func (p Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		s, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %v", prop.Name, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(s)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Options configures the conversion.
+type Options struct {
+
+	// Outputs adds the output types: objects, interfaces and unions
+	Outputs bool
+
+	// Scalars maps custom scalars to schemas, unmapped ones accept any value
+	Scalars map[string]*Schema
+}
+
+var (
+	minInt = float64(math.MinInt32)
+	maxInt = float64(math.MaxInt32)
+)
+
+// Document converts the input objects and enums of the schema document into definitions of a JSON Schema,
+// referred to as #/definitions/<type>. Non-null fields without a default value are required, nullable ones accept null.
+// Input objects accept no other properties than their fields.
+func Document(doc *ast.SchemaDocument, opts Options) (*Schema, error) {
+	doc, _ = merge.Documents(doc)
+	c := &converter{
+		types:   make(map[string]*ast.Definition),
+		scalars: opts.Scalars,
+	}
+	for _, def := range doc.Definitions {
+		c.types[def.Name] = def
+	}
+
+	s := &Schema{Schema: Draft, Definitions: Properties{}}
+	for _, def := range doc.Definitions {
+		var d *Schema
+		var err error
+		switch def.Kind {
+		case ast.InputObject:
+			d, err = c.object(def, true)
+		case ast.Enum:
+			d = c.enum(def)
+		case ast.Object, ast.Interface:
+			if opts.Outputs {
+				d, err = c.object(def, false)
+			}
+		case ast.Union:
+			if opts.Outputs {
+				d = &Schema{Description: def.Description}
+				for _, t := range def.Types {
+					d.AnyOf = append(d.AnyOf, ref(t))
+				}
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("type '%s': %v", def.Name, err)
+		}
+		if d != nil {
+			s.Definitions = append(s.Definitions, Property{Name: def.Name, Schema: d})
+		}
+	}
+	return s, nil
+}
+
+type converter struct {
+	types   map[string]*ast.Definition
+	scalars map[string]*Schema
+}
+
+func (c *converter) enum(def *ast.Definition) *Schema {
+	s := &Schema{Description: def.Description, Type: "string"}
+	for _, v := range def.EnumValues {
+		s.Enum = append(s.Enum, v.Name)
+	}
+	return s
+}
+
+func (c *converter) object(def *ast.Definition, input bool) (*Schema, error) {
+	s := &Schema{
+		Description: def.Description,
+		Type:        "object",
+		Properties:  Properties{},
+	}
+	if input {
+		s.AdditionalProperties = new(bool)
+	}
+	for _, f := range def.Fields {
+		p, err := c.typ(f.Type, input)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %v", f.Name, err)
+		}
+		if f.Description != "" || f.DefaultValue != nil || f.Directives.ForName("deprecated") != nil {
+			// keywords next to a reference are ignored, the reference is wrapped then
+			if p.Ref != "" {
+				p = &Schema{AnyOf: []*Schema{p}}
+			}
+			p.Description = f.Description
+			p.Deprecated = f.Directives.ForName("deprecated") != nil
+		}
+		if f.DefaultValue != nil {
+			v, err := f.DefaultValue.Value(nil)
+			if err != nil {
+				return nil, fmt.Errorf("field '%s': %v", f.Name, err)
+			}
+			p.Default = v
+		}
+		if f.Type.NonNull && f.DefaultValue == nil {
+			s.Required = append(s.Required, f.Name)
+		}
+		s.Properties = append(s.Properties, Property{Name: f.Name, Schema: p})
+	}
+	return s, nil
+}
+
+// typ returns the schema of values of the GraphQL type, the named types other than the built-in scalars are referred to.
+// IDs are strings, input ones may be integers too.
+func (c *converter) typ(t *ast.Type, input bool) (*Schema, error) {
+	var s *Schema
+	if t.Elem != nil {
+		items, err := c.typ(t.Elem, input)
+		if err != nil {
+			return nil, err
+		}
+		s = &Schema{Type: "array", Items: items}
+	} else {
+		def := c.types[t.NamedType]
+		switch {
+		case t.NamedType == "Int":
+			s = &Schema{Type: "integer", Minimum: &minInt, Maximum: &maxInt}
+		case t.NamedType == "Float":
+			s = &Schema{Type: "number"}
+		case t.NamedType == "String":
+			s = &Schema{Type: "string"}
+		case t.NamedType == "Boolean":
+			s = &Schema{Type: "boolean"}
+		case t.NamedType == "ID" && input:
+			s = &Schema{Type: []interface{}{"string", "integer"}}
+		case t.NamedType == "ID":
+			s = &Schema{Type: "string"}
+		case def == nil:
+			return nil, fmt.Errorf("type '%s' not found", t.NamedType)
+		case def.Kind == ast.Scalar:
+			if mapped, ok := c.scalars[def.Name]; ok {
+				cp := *mapped
+				s = &cp
+			} else {
+				// any value
+				return &Schema{}, nil
+			}
+		default:
+			s = ref(def.Name)
+		}
+	}
+
+	if !t.NonNull {
+		return nullable(s), nil
+	}
+	return s, nil
+}
+
+// nullable returns the schema accepting null too.
+func nullable(s *Schema) *Schema {
+	switch t := s.Type.(type) {
+	case string:
+		s.Type = []interface{}{t, "null"}
+		return s
+	case []interface{}:
+		s.Type = append(append([]interface{}{}, t...), "null")
+		return s
+	}
+	if s.Type == nil && s.Ref == "" && s.AnyOf == nil {
+		// any value
+		return s
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/definitions/" + name}
+}
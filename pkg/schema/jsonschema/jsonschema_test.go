@@ -0,0 +1,111 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestDocument(t *testing.T) {
+	testData := []struct {
+		name  string
+		input string
+		opts  Options
+		want  string
+	}{
+		{
+			name:  "enum",
+			input: `"Role of a user" enum Role { ADMIN USER }`,
+			want:  `{"Role":{"description":"Role of a user","type":"string","enum":["ADMIN","USER"]}}`,
+		},
+		{
+			name:  "non-null and nullable",
+			input: `input I { a: String! b: Float c: Boolean }`,
+			want: `{"I":{"type":"object","properties":{"a":{"type":"string"},"b":{"type":["number","null"]},` +
+				`"c":{"type":["boolean","null"]}},"required":["a"],"additionalProperties":false}}`,
+		},
+		{
+			name:  "built-in scalars",
+			input: `input I { a: Int! b: ID! }`,
+			want: `{"I":{"type":"object","properties":{"a":{"type":"integer","minimum":-2147483648,"maximum":2147483647},` +
+				`"b":{"type":["string","integer"]}},"required":["a","b"],"additionalProperties":false}}`,
+		},
+		{
+			name:  "lists",
+			input: `input I { a: [String!]! b: [[Int]] }`,
+			want: `{"I":{"type":"object","properties":{"a":{"type":"array","items":{"type":"string"}},` +
+				`"b":{"type":["array","null"],"items":{"type":["array","null"],"items":{"type":["integer","null"],"minimum":-2147483648,"maximum":2147483647}}}},` +
+				`"required":["a"],"additionalProperties":false}}`,
+		},
+		{
+			name:  "references and defaults",
+			input: `enum E { A B } input J { x: String } input I { e: E! = A j: J "limit" n: String! = "x" }`,
+			want: `{"E":{"type":"string","enum":["A","B"]},` +
+				`"J":{"type":"object","properties":{"x":{"type":["string","null"]}},"additionalProperties":false},` +
+				`"I":{"type":"object","properties":{"e":{"anyOf":[{"$ref":"#/definitions/E"}],"default":"A"},` +
+				`"j":{"anyOf":[{"$ref":"#/definitions/J"},{"type":"null"}]},"n":{"description":"limit","type":"string","default":"x"}},` +
+				`"additionalProperties":false}}`,
+		},
+		{
+			name:  "custom scalars",
+			input: `scalar DateTime scalar JSON input I { a: DateTime b: JSON! }`,
+			opts:  Options{Scalars: map[string]*Schema{"DateTime": {Type: "string", Format: "date-time"}}},
+			want: `{"I":{"type":"object","properties":{"a":{"type":["string","null"],"format":"date-time"},"b":{}},` +
+				`"required":["b"],"additionalProperties":false}}`,
+		},
+		{
+			name:  "output types left out",
+			input: `type T { a: String } union U = T input I { a: String }`,
+			want:  `{"I":{"type":"object","properties":{"a":{"type":["string","null"]}},"additionalProperties":false}}`,
+		},
+		{
+			name:  "output types",
+			input: `interface N { id: ID! } type T implements N { id: ID! a: String @deprecated } union U = T`,
+			opts:  Options{Outputs: true},
+			want: `{"N":{"type":"object","properties":{"id":{"type":"string"}},"required":["id"]},` +
+				`"T":{"type":"object","properties":{"id":{"type":"string"},"a":{"type":["string","null"],"deprecated":true}},"required":["id"]},` +
+				`"U":{"anyOf":[{"$ref":"#/definitions/T"}]}}`,
+		},
+		{
+			name:  "extensions",
+			input: `input I { a: String } extend input I { b: String }`,
+			want:  `{"I":{"type":"object","properties":{"a":{"type":["string","null"]},"b":{"type":["string","null"]}},"additionalProperties":false}}`,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, gqlErr := parser.ParseSchema(&ast.Source{Input: tt.input})
+			if gqlErr != nil {
+				t.Fatal(gqlErr)
+			}
+			s, err := Document(doc, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Schema != Draft {
+				t.Errorf("got $schema %s, want %s", s.Schema, Draft)
+			}
+			b, err := json.Marshal(s.Definitions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentErrors(t *testing.T) {
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Input: `input I { a: Unknown }`})
+	if gqlErr != nil {
+		t.Fatal(gqlErr)
+	}
+	want := "type 'I': field 'a': type 'Unknown' not found"
+	if _, err := Document(doc, Options{}); err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rules can be disabled by comments in the schema source:
+//
+//	# lint-disable [rule, ...]            disables rules until lint-enable
+//	# lint-enable [rule, ...]             enables rules again, also after a lint-disable of all rules
+//	# lint-disable-line [rule, ...]       disables rules on the same line
+//	# lint-disable-next-line [rule, ...]  disables rules on the following line
+//
+// All rules are affected when no rule is listed.
+var commentRegexp = regexp.MustCompile(`#\s*lint-(disable-next-line|disable-line|disable|enable)\b(.*)$`)
+
+const allRules = "*"
+
+// suppressions keep the rules disabled on each line by lint-disable blocks, the exceptions of the blocks made
+// by lint-enable, and the rules disabled by the line comments, which win over the exceptions.
+type suppressions struct {
+	lines   map[int]map[string]bool
+	enabled map[int]map[string]bool
+	single  map[int]map[string]bool
+}
+
+func parseSuppressions(src string) *suppressions {
+	s := &suppressions{
+		lines:   make(map[int]map[string]bool),
+		enabled: make(map[int]map[string]bool),
+		single:  make(map[int]map[string]bool),
+	}
+
+	// Rules enabled while all rules are disabled are kept as exceptions.
+	disabled := make(map[string]bool)
+	enabled := make(map[string]bool)
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		n := i + 1
+		if m := commentRegexp.FindStringSubmatch(line); m != nil {
+			for _, r := range parseRuleNames(m[2]) {
+				switch m[1] {
+				case "disable":
+					if r == allRules {
+						enabled = make(map[string]bool)
+					}
+					disabled[r] = true
+					delete(enabled, r)
+				case "enable":
+					if r == allRules {
+						disabled = make(map[string]bool)
+						enabled = make(map[string]bool)
+					}
+					delete(disabled, r)
+					if disabled[allRules] {
+						enabled[r] = true
+					}
+				case "disable-line":
+					addRule(s.single, n, r)
+				case "disable-next-line":
+					addRule(s.single, n+1, r)
+				}
+			}
+		}
+
+		for r := range disabled {
+			addRule(s.lines, n, r)
+		}
+		for r := range enabled {
+			addRule(s.enabled, n, r)
+		}
+	}
+
+	return s
+}
+
+func parseRuleNames(s string) []string {
+	var names []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r'
+	}) {
+		names = append(names, f)
+	}
+	if len(names) == 0 {
+		names = append(names, allRules)
+	}
+	return names
+}
+
+func addRule(rules map[int]map[string]bool, line int, rule string) {
+	if rules[line] == nil {
+		rules[line] = make(map[string]bool)
+	}
+	rules[line][rule] = true
+}
+
+func (s *suppressions) disabled(line int, rule string) bool {
+	return s.single[line][rule] || s.single[line][allRules] ||
+		s.lines[line][rule] || s.lines[line][allRules] && !s.enabled[line][rule]
+}
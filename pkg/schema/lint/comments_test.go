@@ -0,0 +1,62 @@
+package lint
+
+import "testing"
+
+func TestSuppressions(t *testing.T) {
+	testData := []struct {
+		name     string
+		src      string
+		line     int
+		rule     string
+		disabled bool
+	}{
+		{
+			name:     "Block disables the rule",
+			src:      "# lint-disable a\nx\n# lint-enable a\ny",
+			line:     2,
+			rule:     "a",
+			disabled: true,
+		},
+		{
+			name: "Block ends at lint-enable",
+			src:  "# lint-disable a\nx\n# lint-enable a\ny",
+			line: 4,
+			rule: "a",
+		},
+		{
+			name: "Rule is enabled within disable-all block",
+			src:  "# lint-disable\n# lint-enable a\nx",
+			line: 3,
+			rule: "a",
+		},
+		{
+			name:     "Other rules stay disabled within disable-all block",
+			src:      "# lint-disable\n# lint-enable a\nx",
+			line:     3,
+			rule:     "b",
+			disabled: true,
+		},
+		{
+			name:     "Disable-line wins over enabled rule",
+			src:      "# lint-disable\n# lint-enable a\nx # lint-disable-line",
+			line:     3,
+			rule:     "a",
+			disabled: true,
+		},
+		{
+			name:     "Disable-next-line wins over enabled rule",
+			src:      "# lint-disable\n# lint-enable a\n# lint-disable-next-line a\nx",
+			line:     4,
+			rule:     "a",
+			disabled: true,
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			if disabled := parseSuppressions(s.src).disabled(s.line, s.rule); disabled != s.disabled {
+				t.Errorf("invalid suppression of rule '%s' on line %d: want %v, have %v", s.rule, s.line, s.disabled, disabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+// Schema lints a GraphQL schema encoded using SDL.
+func Schema(name string, r io.Reader, cfg Config) ([]Diagnostic, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read schema '%s': %v", name, err)
+	}
+
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Name: name, Input: string(b)})
+	if gqlErr != nil {
+		return nil, fmt.Errorf("unable to parse schema '%s': %v", name, gqlErr)
+	}
+
+	return Document(doc, cfg)
+}
+
+// Document lints an already parsed schema, see Schema.
+// Inline comments disabling rules are honored as long as the document positions refer to its source.
+func Document(doc *ast.SchemaDocument, cfg Config) ([]Diagnostic, error) {
+	l := &linter{
+		suppressions: make(map[*ast.Source]*suppressions),
+	}
+
+	for _, r := range rules {
+		sev, err := cfg.severity(r)
+		if err != nil {
+			return nil, err
+		}
+		if sev == Off {
+			continue
+		}
+		Walk(doc, r.Visitor(l.reporter(r.Name(), sev)))
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		x, y := l.diagnostics[i].Position, l.diagnostics[j].Position
+		if x == nil || y == nil {
+			return x != nil
+		}
+		if x.Line != y.Line {
+			return x.Line < y.Line
+		}
+		return x.Column < y.Column
+	})
+	return l.diagnostics, nil
+}
+
+// Severity of a diagnostic.
+type Severity string
+
+const (
+	// Off disables the rule
+	Off = Severity("off")
+
+	// Warning reports the diagnostic but does not fail the linting
+	Warning = Severity("warning")
+
+	// Error reports the diagnostic and fails the linting
+	Error = Severity("error")
+)
+
+// Diagnostic is a violation of a lint rule.
+type Diagnostic struct {
+
+	// Rule which reported the diagnostic
+	Rule string `json:"rule"`
+
+	// Severity of the diagnostic
+	Severity Severity `json:"severity"`
+
+	// Message provides human-readable explanation of the violation
+	Message string `json:"message"`
+
+	// Path to the offending item
+	Path string `json:"path"`
+
+	// Position of the offending item in the source, if known
+	Position *ast.Position `json:"-"`
+}
+
+func (d Diagnostic) String() string {
+	var loc []string
+	if d.Position != nil {
+		if d.Position.Src != nil && d.Position.Src.Name != "" {
+			loc = append(loc, d.Position.Src.Name)
+		}
+		loc = append(loc, fmt.Sprint(d.Position.Line), fmt.Sprint(d.Position.Column))
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", strings.Join(loc, ":"), d.Severity, d.Message, d.Rule)
+}
+
+// MarshalJSON encodes the diagnostic including its position.
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	type diagnostic Diagnostic
+	v := struct {
+		diagnostic
+		File   string `json:"file,omitempty"`
+		Line   int    `json:"line,omitempty"`
+		Column int    `json:"column,omitempty"`
+	}{diagnostic: diagnostic(d)}
+	if d.Position != nil {
+		if d.Position.Src != nil {
+			v.File = d.Position.Src.Name
+		}
+		v.Line, v.Column = d.Position.Line, d.Position.Column
+	}
+	return json.Marshal(v)
+}
+
+// Config overrides the default severity of rules.
+type Config struct {
+
+	// Presets enable sets of rules, see Presets
+	Presets []string `json:"presets,omitempty"`
+
+	// Rules maps rule names to severities, it takes precedence over the presets
+	Rules map[string]Severity `json:"rules"`
+}
+
+// LoadConfig reads the JSON encoded configuration, e.g. {"rules": {"type-description-required": "off"}}.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+	if err := json.NewDecoder(r).Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("unable to read lint configuration: %v", err)
+	}
+	for _, name := range cfg.Presets {
+		if _, ok := presets[name]; !ok {
+			return Config{}, fmt.Errorf("unknown lint preset '%s'", name)
+		}
+	}
+	for name := range cfg.Rules {
+		r := findRule(name)
+		if r == nil {
+			return Config{}, fmt.Errorf("unknown lint rule '%s'", name)
+		}
+		if _, err := cfg.severity(r); err != nil {
+			return Config{}, err
+		}
+	}
+	return cfg, nil
+}
+
+func (cfg Config) severity(r Rule) (Severity, error) {
+	sev, ok := cfg.Rules[r.Name()]
+	for i := len(cfg.Presets) - 1; i >= 0 && !ok; i-- {
+		p, found := presets[cfg.Presets[i]]
+		if !found {
+			return "", fmt.Errorf("unknown lint preset '%s'", cfg.Presets[i])
+		}
+		sev, ok = p[r.Name()]
+	}
+	if !ok {
+		sev = r.Severity()
+	}
+	switch sev {
+	case Off, Warning, Error:
+		return sev, nil
+	default:
+		return "", fmt.Errorf("invalid severity '%s' of lint rule '%s'", sev, r.Name())
+	}
+}
+
+// HasErrors reports whether any of the diagnostics is an error.
+func HasErrors(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	diagnostics  []Diagnostic
+	suppressions map[*ast.Source]*suppressions
+}
+
+// reporter reports diagnostics of the rule unless they are disabled by inline comments.
+func (l *linter) reporter(rule string, sev Severity) Reporter {
+	return func(pos *ast.Position, path string, format string, args ...interface{}) {
+		if pos != nil && pos.Src != nil {
+			s, ok := l.suppressions[pos.Src]
+			if !ok {
+				s = parseSuppressions(pos.Src.Input)
+				l.suppressions[pos.Src] = s
+			}
+			if s.disabled(pos.Line, rule) {
+				return
+			}
+		}
+
+		l.diagnostics = append(l.diagnostics, Diagnostic{
+			Rule:     rule,
+			Severity: sev,
+			Message:  fmt.Sprintf(format, args...),
+			Path:     path,
+			Position: pos,
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestSchema(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema string
+		config string
+		want   []string
+	}{
+		{
+			name: "Conforming schema has no diagnostics",
+			schema: `
+"Query"
+type Query {
+  heroes(firstN: Int): [Hero] @deprecated(reason: "Use characters")
+}
+"Hero"
+type Hero { name: String episode: Episode }
+"Episode"
+enum Episode { NEW_HOPE EMPIRE }
+"Hero input"
+input HeroInput { name: String }
+`,
+		},
+		{
+			name: "Naming conventions are enforced",
+			schema: `
+"Query"
+type query {
+  Hero(First: Int): String
+}
+"Episode"
+enum Episode { newHope }
+`,
+			want: []string{
+				"3:6 type-name-pascal-case query",
+				"4:3 field-name-camel-case query.Hero",
+				"4:8 argument-name-camel-case query.Hero.First",
+				"7:16 enum-value-upper-case Episode.newHope",
+			},
+		},
+		{
+			name: "Descriptions are required on types",
+			schema: `
+type Query { a: String }
+extend type Query { b: String }
+`,
+			want: []string{
+				"2:6 type-description-required Query",
+			},
+		},
+		{
+			name:   "Output types must not end with Input",
+			schema: `"Hero" type HeroInput { a: String }`,
+			want: []string{
+				"1:13 output-type-no-input-suffix HeroInput",
+			},
+		},
+		{
+			name:   "Deprecations must have reason",
+			schema: `"Q" type Query { a: String @deprecated b: String @deprecated(reason: "Use a") }`,
+			want: []string{
+				"1:29 deprecation-reason-required Query.a",
+			},
+		},
+		{
+			name:   "Rules can be configured",
+			schema: `type Query { A: String }`,
+			config: `{"rules": {"type-description-required": "off", "field-description-required": "warning"}}`,
+			want: []string{
+				"1:14 field-name-camel-case Query.A",
+				"1:14 field-description-required Query.A",
+			},
+		},
+		{
+			name: "Rules can be disabled by comments",
+			schema: `
+# lint-disable type-description-required
+type Query {
+  A: String # lint-disable-line field-name-camel-case
+  # lint-disable-next-line
+  B: String
+  C: String
+}
+# lint-enable type-description-required
+type lower { a: String }
+`,
+			want: []string{
+				"7:3 field-name-camel-case Query.C",
+				"10:6 type-name-pascal-case lower",
+				"10:6 type-description-required lower",
+			},
+		},
+		{
+			name: "Rules can be enabled after disabling all rules",
+			schema: `
+# lint-disable
+type lower { A: String } # lint-enable field-name-camel-case
+type Query { B: String }
+# lint-disable field-name-camel-case
+type Other { C: String }
+`,
+			want: []string{
+				"3:14 field-name-camel-case lower.A",
+				"4:14 field-name-camel-case Query.B",
+			},
+		},
+		{
+			name: "Relay preset accepts compliant schema",
+			schema: `
+type Query {
+  node(id: ID!): Node
+  ships(first: Int, after: String, last: Int, before: String): ShipConnection
+}
+type Mutation { introduceShip(input: IntroduceShipInput!): IntroduceShipPayload }
+interface Node { id: ID! }
+type Ship implements Node { id: ID! name: String }
+type ShipConnection { edges: [ShipEdge] pageInfo: PageInfo! }
+type ShipEdge { cursor: String! node: Ship }
+type PageInfo { hasPreviousPage: Boolean! hasNextPage: Boolean! startCursor: String endCursor: String }
+input IntroduceShipInput { shipName: String! clientMutationId: String }
+type IntroduceShipPayload { ship: Ship clientMutationId: String }
+`,
+			config: `{"presets": ["relay"], "rules": {"type-description-required": "off"}}`,
+		},
+		{
+			name: "Relay preset reports violations",
+			schema: `
+type Query {
+  ships(first: Int): ShipConnection
+}
+type Mutation { introduceShip(name: String): Ship }
+interface Node { key: ID! }
+type Ship implements Node { key: ID! }
+type ShipConnection { edges: [ShipEdge] }
+type ShipEdge { node: Ship }
+type PageInfo { hasNextPage: Boolean }
+`,
+			config: `{"presets": ["relay"], "rules": {"type-description-required": "off"}}`,
+			want: []string{
+				"2:6 relay-node-field Query.node",
+				"3:3 relay-connection-arguments Query.ships",
+				"5:17 relay-mutation-input Mutation.introduceShip",
+				"6:11 relay-node-interface Node.id",
+				"7:6 relay-mutation-input Ship.clientMutationId",
+				"8:6 relay-connection-type ShipConnection.pageInfo",
+				"9:6 relay-edge-type ShipEdge.cursor",
+				"10:6 relay-page-info-type PageInfo.hasPreviousPage",
+				"10:6 relay-page-info-type PageInfo.hasNextPage",
+				"10:6 relay-page-info-type PageInfo.startCursor",
+				"10:6 relay-page-info-type PageInfo.endCursor",
+			},
+		},
+		{
+			name: "Relay preset requires an input object argument",
+			schema: `
+type Query { a: String }
+type Mutation { rename(input: String!): RenamePayload }
+type RenamePayload { clientMutationId: String }
+`,
+			config: `{"presets": ["relay"], "rules": {"type-description-required": "off"}}`,
+			want: []string{
+				"2:1 relay-node-interface Node",
+				"2:6 relay-node-field Query.node",
+				"3:17 relay-mutation-input Mutation.rename",
+			},
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			var cfg Config
+			if s.config != "" {
+				var err error
+				if cfg, err = LoadConfig(strings.NewReader(s.config)); err != nil {
+					t.Fatalf("unable to load config: %v", err)
+				}
+			}
+
+			diagnostics, err := Schema("test.graphql", strings.NewReader(s.schema), cfg)
+			if err != nil {
+				t.Fatalf("unable to lint schema: %v", err)
+			}
+
+			var have []string
+			for _, d := range diagnostics {
+				have = append(have, fmt.Sprintf("%d:%d %s %s", d.Position.Line, d.Position.Column, d.Rule, d.Path))
+			}
+			if !reflect.DeepEqual(s.want, have) {
+				t.Errorf("invalid diagnostics:\nwant %q\nhave %q", s.want, have)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	for _, s := range []string{
+		`{"rules": {"unknown": "off"}}`,
+		`{"rules": {"type-name-pascal-case": "fatal"}}`,
+		`{"rules": []}`,
+		`{"presets": ["unknown"]}`,
+	} {
+		if _, err := LoadConfig(strings.NewReader(s)); err == nil {
+			t.Errorf("config %q should be invalid", s)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(NewRule(
+		"mutation-single-input",
+		"Mutations must take a single argument named 'input'.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Field: func(def *ast.Definition, f *ast.FieldDefinition) {
+					if def.Name == "Mutation" && (len(f.Arguments) != 1 || f.Arguments[0].Name != "input") {
+						report(f.Position, def.Name+"."+f.Name, "Mutation '%s' must take a single argument named 'input'", f.Name)
+					}
+				},
+			}
+		},
+	))
+
+	schema := `
+"Mutation"
+type Mutation {
+  a(input: AInput!): String
+  b(id: ID!): String
+}
+"A input"
+input AInput { id: ID! }
+`
+	diagnostics, err := Schema("test.graphql", strings.NewReader(schema), Config{})
+	if err != nil {
+		t.Fatalf("unable to lint schema: %v", err)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Rule != "mutation-single-input" || diagnostics[0].Path != "Mutation.b" {
+		t.Errorf("invalid diagnostics: %v", diagnostics)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering rule twice should panic")
+		}
+	}()
+	Register(NewRule("mutation-single-input", "", Error, nil))
+}
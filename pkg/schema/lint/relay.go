@@ -0,0 +1,276 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// relayRules check compliance with the Relay specifications of object identification,
+// cursor connections and input object mutations, see https://relay.dev/docs/guides/graphql-server-specification/.
+var relayRules = []Rule{
+	NewRule(
+		"relay-node-interface",
+		"Interface 'Node' must define field 'id: ID!'.",
+		Off,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					types := mergeDefinitions(doc)
+					node, ok := types["Node"]
+					switch {
+					case !ok:
+						report(doc.Position, "Node", "Interface 'Node' must be defined")
+					case node.Kind != ast.Interface:
+						report(node.Position, "Node", "Type 'Node' must be %s, not %s", ast.Interface, node.Kind)
+					default:
+						if id := node.Fields.ForName("id"); id == nil || id.Type.String() != "ID!" {
+							report(node.Position, "Node.id", "Interface 'Node' must define field 'id: ID!'")
+						}
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"relay-node-field",
+		"Query type must define field 'node(id: ID!): Node'.",
+		Off,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					query := mergeDefinitions(doc)[rootTypeName(doc, ast.Query)]
+					if query == nil {
+						return
+					}
+					f := query.Fields.ForName("node")
+					if f == nil {
+						report(query.Position, query.Name+".node", "Type '%s' must define field 'node(id: ID!): Node'", query.Name)
+						return
+					}
+					id := f.Arguments.ForName("id")
+					if f.Type.String() != "Node" || id == nil || id.Type.String() != "ID!" || len(f.Arguments) != 1 {
+						report(f.Position, query.Name+".node", "Field '%s.node' must be defined as 'node(id: ID!): Node'", query.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"relay-connection-type",
+		"Connection types must define fields 'edges' and 'pageInfo: PageInfo!'.",
+		Off,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					types := mergeDefinitions(doc)
+					for _, def := range doc.Definitions {
+						if def.Kind != ast.Object || !strings.HasSuffix(def.Name, "Connection") {
+							continue
+						}
+						def = types[def.Name]
+
+						edges := def.Fields.ForName("edges")
+						if edges == nil {
+							report(def.Position, def.Name+".edges", "Connection '%s' must define field 'edges'", def.Name)
+						} else if edge := types[edges.Type.Name()]; edges.Type.Elem == nil || edge == nil || edge.Kind != ast.Object {
+							report(edges.Position, def.Name+".edges", "Field '%s.edges' must return a list of edge types", def.Name)
+						}
+
+						if pageInfo := def.Fields.ForName("pageInfo"); pageInfo == nil || pageInfo.Type.String() != "PageInfo!" {
+							report(def.Position, def.Name+".pageInfo", "Connection '%s' must define field 'pageInfo: PageInfo!'", def.Name)
+						}
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"relay-edge-type",
+		"Edge types must define fields 'node' and 'cursor'.",
+		Off,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					types := mergeDefinitions(doc)
+					for _, def := range doc.Definitions {
+						if def.Kind != ast.Object || !strings.HasSuffix(def.Name, "Connection") {
+							continue
+						}
+						edges := types[def.Name].Fields.ForName("edges")
+						if edges == nil {
+							continue
+						}
+						edge := types[edges.Type.Name()]
+						if edge == nil || edge.Kind != ast.Object {
+							continue
+						}
+
+						node := edge.Fields.ForName("node")
+						if node == nil {
+							report(edge.Position, edge.Name+".node", "Edge '%s' must define field 'node'", edge.Name)
+						} else if t := types[node.Type.Name()]; node.Type.Elem != nil || t == nil || t.Kind == ast.InputObject {
+							report(node.Position, edge.Name+".node", "Field '%s.node' must return a scalar, enum, object, interface or union", edge.Name)
+						}
+
+						cursor := edge.Fields.ForName("cursor")
+						if cursor == nil {
+							report(edge.Position, edge.Name+".cursor", "Edge '%s' must define field 'cursor'", edge.Name)
+						} else if !isCursorType(types, cursor.Type) {
+							report(cursor.Position, edge.Name+".cursor", "Field '%s.cursor' must return a type serialized as a string", edge.Name)
+						}
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"relay-page-info-type",
+		"Type 'PageInfo' must define fields 'hasPreviousPage: Boolean!', 'hasNextPage: Boolean!', 'startCursor' and 'endCursor'.",
+		Off,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					types := mergeDefinitions(doc)
+					pageInfo, ok := types["PageInfo"]
+					if !ok {
+						return // reported by relay-connection-type if there is any connection
+					}
+					if pageInfo.Kind != ast.Object {
+						report(pageInfo.Position, "PageInfo", "Type 'PageInfo' must be %s, not %s", ast.Object, pageInfo.Kind)
+						return
+					}
+					for _, name := range []string{"hasPreviousPage", "hasNextPage"} {
+						if f := pageInfo.Fields.ForName(name); f == nil || f.Type.String() != "Boolean!" {
+							report(pageInfo.Position, "PageInfo."+name, "Type 'PageInfo' must define field '%s: Boolean!'", name)
+						}
+					}
+					for _, name := range []string{"startCursor", "endCursor"} {
+						if f := pageInfo.Fields.ForName(name); f == nil || !isCursorType(types, f.Type) {
+							report(pageInfo.Position, "PageInfo."+name, "Type 'PageInfo' must define field '%s' of a type serialized as a string", name)
+						}
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"relay-connection-arguments",
+		"Fields returning connections must define arguments 'first' and 'after', or 'last' and 'before'.",
+		Off,
+		func(report Reporter) *Visitor {
+			var types map[string]*ast.Definition
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					types = mergeDefinitions(doc)
+				},
+				Field: func(def *ast.Definition, f *ast.FieldDefinition) {
+					t := types[f.Type.Name()]
+					if def.Kind == ast.InputObject || f.Type.Elem != nil || t == nil || t.Kind != ast.Object || !strings.HasSuffix(t.Name, "Connection") {
+						return
+					}
+					hasPagination := func(count, cursor string) bool {
+						c, a := f.Arguments.ForName(count), f.Arguments.ForName(cursor)
+						return c != nil && c.Type.Name() == "Int" && a != nil && isCursorType(types, a.Type)
+					}
+					if !hasPagination("first", "after") && !hasPagination("last", "before") {
+						report(f.Position, def.Name+"."+f.Name, "Field '%s.%s' must define arguments 'first: Int' and 'after', or 'last: Int' and 'before'", def.Name, f.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"relay-mutation-input",
+		"Mutations must take a single non-null 'input' argument and return a payload, both with field 'clientMutationId: String'.",
+		Off,
+		func(report Reporter) *Visitor {
+			var (
+				types    map[string]*ast.Definition
+				mutation string
+			)
+			return &Visitor{
+				Document: func(doc *ast.SchemaDocument) {
+					types = mergeDefinitions(doc)
+					mutation = rootTypeName(doc, ast.Mutation)
+				},
+				Field: func(def *ast.Definition, f *ast.FieldDefinition) {
+					if def.Name != mutation {
+						return
+					}
+					path := def.Name + "." + f.Name
+
+					input := f.Arguments.ForName("input")
+					var t *ast.Definition
+					if input != nil {
+						t = types[input.Type.Name()]
+					}
+					if len(f.Arguments) != 1 || t == nil || t.Kind != ast.InputObject || !input.Type.NonNull || input.Type.Elem != nil {
+						report(f.Position, path, "Mutation '%s' must take a single argument 'input' of a non-null input object", f.Name)
+					} else if !hasClientMutationID(t) {
+						report(t.Position, t.Name+".clientMutationId", "Input '%s' of mutation '%s' must define field 'clientMutationId: String'", t.Name, f.Name)
+					}
+
+					if t := types[f.Type.Name()]; t == nil || t.Kind != ast.Object || f.Type.Elem != nil {
+						report(f.Position, path, "Mutation '%s' must return a payload object", f.Name)
+					} else if !hasClientMutationID(t) {
+						report(t.Position, t.Name+".clientMutationId", "Payload '%s' of mutation '%s' must define field 'clientMutationId: String'", t.Name, f.Name)
+					}
+				},
+			}
+		},
+	),
+}
+
+func init() {
+	var rules = make(map[string]Severity)
+	for _, r := range relayRules {
+		rules[r.Name()] = Error
+	}
+	RegisterPreset("relay", rules)
+}
+
+// mergeDefinitions returns the definitions of the document with their extensions applied.
+func mergeDefinitions(doc *ast.SchemaDocument) map[string]*ast.Definition {
+	types := make(map[string]*ast.Definition)
+	for _, def := range doc.Definitions {
+		if _, ok := types[def.Name]; !ok {
+			cp := *def
+			types[def.Name] = &cp
+		}
+	}
+	for _, ext := range doc.Extensions {
+		if def, ok := types[ext.Name]; ok {
+			def.Fields = append(append(ast.FieldList(nil), def.Fields...), ext.Fields...)
+			def.Interfaces = append(append([]string(nil), def.Interfaces...), ext.Interfaces...)
+		}
+	}
+	return types
+}
+
+func rootTypeName(doc *ast.SchemaDocument, op ast.Operation) string {
+	for _, list := range []ast.SchemaDefinitionList{doc.Schema, doc.SchemaExtension} {
+		for _, def := range list {
+			if t := def.OperationTypes.ForType(string(op)); t != nil {
+				return t.Type
+			}
+		}
+	}
+	return strings.Title(string(op))
+}
+
+func isCursorType(types map[string]*ast.Definition, t *ast.Type) bool {
+	if t.Elem != nil {
+		return false
+	}
+	if t.NamedType == "String" {
+		return true
+	}
+	def, ok := types[t.NamedType]
+	return ok && def.Kind == ast.Scalar
+}
+
+func hasClientMutationID(def *ast.Definition) bool {
+	f := def.Fields.ForName("clientMutationId")
+	return f != nil && f.Type.String() == "String"
+}
@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Rule checks a schema document and reports violations.
+type Rule interface {
+
+	// Name identifies the rule in the configuration and inline comments
+	Name() string
+
+	// Description provides human-readable explanation of the rule
+	Description() string
+
+	// Severity is used unless the configuration overrides it
+	Severity() Severity
+
+	// Visitor returns the visitor walking the document, violations are reported using the given reporter
+	Visitor(report Reporter) *Visitor
+}
+
+// Reporter reports a violation of a rule located at the given position,
+// path refers to the offending item, e.g. 'Query.hero.episode'.
+type Reporter func(pos *ast.Position, path string, format string, args ...interface{})
+
+// NewRule returns a rule reporting violations detected by the visitor.
+func NewRule(name, description string, severity Severity, visitor func(report Reporter) *Visitor) Rule {
+	return funcRule{name: name, description: description, severity: severity, visitor: visitor}
+}
+
+type funcRule struct {
+	name        string
+	description string
+	severity    Severity
+	visitor     func(report Reporter) *Visitor
+}
+
+func (r funcRule) Name() string                     { return r.name }
+func (r funcRule) Description() string              { return r.description }
+func (r funcRule) Severity() Severity               { return r.severity }
+func (r funcRule) Visitor(report Reporter) *Visitor { return r.visitor(report) }
+
+var rules []Rule
+
+// Register adds the rule to the rules checked by Schema and Document.
+// Rules are expected to be registered during initialization, Register is not safe for concurrent use.
+func Register(r Rule) {
+	if findRule(r.Name()) != nil {
+		panic(fmt.Errorf("lint rule '%s' is already registered", r.Name()))
+	}
+	rules = append(rules, r)
+}
+
+// Rules returns all registered rules, built-in rules included.
+func Rules() []Rule {
+	return append([]Rule(nil), rules...)
+}
+
+func findRule(name string) Rule {
+	for _, r := range rules {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+var presets = make(map[string]map[string]Severity)
+
+// RegisterPreset adds a named set of rule severities which can be enabled by Config.Presets.
+func RegisterPreset(name string, rules map[string]Severity) {
+	if _, ok := presets[name]; ok {
+		panic(fmt.Errorf("lint preset '%s' is already registered", name))
+	}
+	presets[name] = rules
+}
+
+// Presets returns names of all registered presets.
+func Presets() []string {
+	var names []string
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,141 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	camelCase  = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	upperCase  = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+func init() {
+	for _, r := range builtInRules {
+		Register(r)
+	}
+	for _, r := range relayRules {
+		Register(r)
+	}
+}
+
+var builtInRules = []Rule{
+	NewRule(
+		"type-name-pascal-case",
+		"Type names must be PascalCase.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Definition: func(def *ast.Definition) {
+					if !pascalCase.MatchString(def.Name) {
+						report(def.Position, def.Name, "Type name '%s' must be PascalCase", def.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"field-name-camel-case",
+		"Field and input field names must be camelCase.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Field: func(def *ast.Definition, f *ast.FieldDefinition) {
+					if !camelCase.MatchString(f.Name) {
+						report(f.Position, def.Name+"."+f.Name, "Field name '%s.%s' must be camelCase", def.Name, f.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"argument-name-camel-case",
+		"Argument names must be camelCase.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Argument: func(def *ast.Definition, f *ast.FieldDefinition, arg *ast.ArgumentDefinition) {
+					if !camelCase.MatchString(arg.Name) {
+						report(arg.Position, def.Name+"."+f.Name+"."+arg.Name, "Argument name '%s' on field '%s.%s' must be camelCase", arg.Name, def.Name, f.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"enum-value-upper-case",
+		"Enum values must be UPPER_CASE.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				EnumValue: func(def *ast.Definition, v *ast.EnumValueDefinition) {
+					if !upperCase.MatchString(v.Name) {
+						report(v.Position, def.Name+"."+v.Name, "Enum value '%s.%s' must be UPPER_CASE", def.Name, v.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"type-description-required",
+		"Types must have a description.",
+		Warning,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Definition: func(def *ast.Definition) {
+					if strings.TrimSpace(def.Description) == "" {
+						report(def.Position, def.Name, "Type '%s' must have a description", def.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"field-description-required",
+		"Fields must have a description.",
+		Off,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Field: func(def *ast.Definition, f *ast.FieldDefinition) {
+					if strings.TrimSpace(f.Description) == "" {
+						report(f.Position, def.Name+"."+f.Name, "Field '%s.%s' must have a description", def.Name, f.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"output-type-no-input-suffix",
+		"Output type names must not end with 'Input'.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Definition: func(def *ast.Definition) {
+					if def.Kind != ast.InputObject && strings.HasSuffix(def.Name, "Input") {
+						report(def.Position, def.Name, "%s type '%s' must not end with 'Input'", def.Kind, def.Name)
+					}
+				},
+			}
+		},
+	),
+	NewRule(
+		"deprecation-reason-required",
+		"Deprecations must provide a reason.",
+		Error,
+		func(report Reporter) *Visitor {
+			return &Visitor{
+				Directive: func(path string, d *ast.Directive) {
+					if d.Name != "deprecated" {
+						return
+					}
+					if reason := d.Arguments.ForName("reason"); reason == nil || strings.TrimSpace(reason.Value.Raw) == "" {
+						report(d.Position, path, "Deprecation of '%s' must provide a reason", path)
+					}
+				},
+			}
+		},
+	),
+}
@@ -0,0 +1,109 @@
+package lint
+
+import (
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Visitor is notified about the items of a schema document, nil functions are skipped.
+type Visitor struct {
+
+	// Document is called before any other item is visited
+	Document func(doc *ast.SchemaDocument)
+
+	// Schema is called for schema definitions and extensions
+	Schema func(def *ast.SchemaDefinition)
+
+	// DirectiveDefinition is called for directive definitions
+	DirectiveDefinition func(def *ast.DirectiveDefinition)
+
+	// Definition is called for type definitions
+	Definition func(def *ast.Definition)
+
+	// Extension is called for type extensions
+	Extension func(def *ast.Definition)
+
+	// Field is called for fields and input fields of both definitions and extensions
+	Field func(def *ast.Definition, f *ast.FieldDefinition)
+
+	// Argument is called for field arguments
+	Argument func(def *ast.Definition, f *ast.FieldDefinition, arg *ast.ArgumentDefinition)
+
+	// EnumValue is called for enum values
+	EnumValue func(def *ast.Definition, v *ast.EnumValueDefinition)
+
+	// Directive is called for applied directives, path refers to the item the directive is applied to
+	Directive func(path string, d *ast.Directive)
+}
+
+// Walk visits the document items in the order of their definition, type extensions included.
+func Walk(doc *ast.SchemaDocument, v *Visitor) {
+	if v.Document != nil {
+		v.Document(doc)
+	}
+
+	for _, list := range []ast.SchemaDefinitionList{doc.Schema, doc.SchemaExtension} {
+		for _, def := range list {
+			if v.Schema != nil {
+				v.Schema(def)
+			}
+			walkDirectives(v, "schema", def.Directives)
+		}
+	}
+
+	for _, def := range doc.Directives {
+		if v.DirectiveDefinition != nil {
+			v.DirectiveDefinition(def)
+		}
+		for _, arg := range def.Arguments {
+			walkDirectives(v, "@"+def.Name+"."+arg.Name, arg.Directives)
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		if v.Definition != nil {
+			v.Definition(def)
+		}
+		walkDefinition(v, def)
+	}
+
+	for _, def := range doc.Extensions {
+		if v.Extension != nil {
+			v.Extension(def)
+		}
+		walkDefinition(v, def)
+	}
+}
+
+func walkDefinition(v *Visitor, def *ast.Definition) {
+	walkDirectives(v, def.Name, def.Directives)
+
+	for _, f := range def.Fields {
+		if v.Field != nil {
+			v.Field(def, f)
+		}
+		walkDirectives(v, def.Name+"."+f.Name, f.Directives)
+
+		for _, arg := range f.Arguments {
+			if v.Argument != nil {
+				v.Argument(def, f, arg)
+			}
+			walkDirectives(v, def.Name+"."+f.Name+"."+arg.Name, arg.Directives)
+		}
+	}
+
+	for _, val := range def.EnumValues {
+		if v.EnumValue != nil {
+			v.EnumValue(def, val)
+		}
+		walkDirectives(v, def.Name+"."+val.Name, val.Directives)
+	}
+}
+
+func walkDirectives(v *Visitor, path string, dirs ast.DirectiveList) {
+	if v.Directive == nil {
+		return
+	}
+	for _, d := range dirs {
+		v.Directive(path, d)
+	}
+}
@@ -0,0 +1,321 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Documents merges schema documents into a single one: extensions are applied to the extended definitions
+// and definitions repeated in several documents are deduplicated as long as they are identical.
+// The merged document can be printed by the format package or compared by compare.Documents.
+// Definitions in conflict are reported and the first one is kept.
+func Documents(docs ...*ast.SchemaDocument) (*ast.SchemaDocument, []Conflict) {
+	m := &merger{
+		doc:        &ast.SchemaDocument{},
+		directives: make(map[string]*ast.DirectiveDefinition),
+		types:      make(map[string]*ast.Definition),
+	}
+	if len(docs) > 0 {
+		m.doc.Position = docs[0].Position
+	}
+
+	// extensions may extend definitions of any document
+	for _, doc := range docs {
+		for _, def := range doc.Schema {
+			m.mergeSchema(def)
+		}
+		for _, def := range doc.Directives {
+			m.mergeDirectiveDefinition(def)
+		}
+		for _, def := range doc.Definitions {
+			m.mergeDefinition(def)
+		}
+	}
+	for _, doc := range docs {
+		for _, def := range doc.SchemaExtension {
+			m.mergeSchema(def)
+		}
+		for _, ext := range doc.Extensions {
+			m.mergeExtension(ext)
+		}
+	}
+
+	return m.doc, m.conflicts
+}
+
+// Conflict is a definition which cannot be merged with a previous one.
+type Conflict struct {
+
+	// Message provides human-readable explanation of the conflict
+	Message string `json:"message"`
+
+	// Path of the conflicting item
+	Path string `json:"path"`
+
+	// Position of the conflicting item, if known
+	Position *ast.Position `json:"-"`
+
+	// Previous is the position of the item it conflicts with, if known
+	Previous *ast.Position `json:"-"`
+}
+
+func (c Conflict) Error() string {
+	s := c.Message
+	if c.Position != nil {
+		s = location(c.Position) + ": " + s
+	}
+	if c.Previous != nil {
+		s += fmt.Sprintf(" (previously defined at %s)", location(c.Previous))
+	}
+	return s
+}
+
+func location(pos *ast.Position) string {
+	var loc []string
+	if pos.Src != nil && pos.Src.Name != "" {
+		loc = append(loc, pos.Src.Name)
+	}
+	loc = append(loc, fmt.Sprint(pos.Line), fmt.Sprint(pos.Column))
+	return strings.Join(loc, ":")
+}
+
+type merger struct {
+	doc        *ast.SchemaDocument
+	schema     *ast.SchemaDefinition
+	directives map[string]*ast.DirectiveDefinition
+	types      map[string]*ast.Definition
+	conflicts  []Conflict
+}
+
+func (m *merger) report(path string, pos, prev *ast.Position, format string, args ...interface{}) {
+	m.conflicts = append(m.conflicts, Conflict{
+		Message:  fmt.Sprintf(format, args...),
+		Path:     path,
+		Position: pos,
+		Previous: prev,
+	})
+}
+
+func (m *merger) mergeSchema(def *ast.SchemaDefinition) {
+	if m.schema == nil {
+		m.schema = &ast.SchemaDefinition{Description: def.Description, Position: def.Position}
+		m.doc.Schema = append(m.doc.Schema, m.schema)
+	}
+	if m.schema.Description == "" {
+		m.schema.Description = def.Description
+	}
+
+	for _, op := range def.OperationTypes {
+		var prev *ast.OperationTypeDefinition
+		for _, o := range m.schema.OperationTypes {
+			if o.Operation == op.Operation {
+				prev = o
+			}
+		}
+		switch {
+		case prev == nil:
+			m.schema.OperationTypes = append(m.schema.OperationTypes, op)
+		case prev.Type != op.Type:
+			m.report(string(op.Operation), op.Position, prev.Position, "Root operation type '%s' is defined as '%s' and '%s'", op.Operation, prev.Type, op.Type)
+		}
+	}
+
+	m.schema.Directives = m.mergeDirectives("schema", m.schema.Directives, def.Directives)
+}
+
+func (m *merger) mergeDirectiveDefinition(def *ast.DirectiveDefinition) {
+	prev, ok := m.directives[def.Name]
+	if !ok {
+		m.directives[def.Name] = def
+		m.doc.Directives = append(m.doc.Directives, def)
+		return
+	}
+
+	if !sameArguments(prev.Arguments, def.Arguments) || !sameLocations(prev.Locations, def.Locations) {
+		m.report("@"+def.Name, def.Position, prev.Position, "Directive '@%s' is defined differently", def.Name)
+	}
+}
+
+func (m *merger) mergeDefinition(def *ast.Definition) {
+	prev, ok := m.types[def.Name]
+	if !ok {
+		cp := copyDefinition(def)
+		m.types[def.Name] = cp
+		m.doc.Definitions = append(m.doc.Definitions, cp)
+		return
+	}
+
+	if prev.Kind != def.Kind {
+		m.report(def.Name, def.Position, prev.Position, "Type '%s' is defined as %s and %s", def.Name, prev.Kind, def.Kind)
+		return
+	}
+	if prev.Description == "" {
+		prev.Description = def.Description
+	}
+
+	for _, f := range def.Fields {
+		switch pf := prev.Fields.ForName(f.Name); {
+		case pf == nil:
+			m.report(def.Name+"."+f.Name, f.Position, prev.Position, "Field '%s.%s' is not defined by all definitions of type '%s'", def.Name, f.Name, def.Name)
+		case !sameField(pf, f):
+			m.report(def.Name+"."+f.Name, f.Position, pf.Position, "Field '%s.%s' is defined differently", def.Name, f.Name)
+		}
+	}
+	for _, pf := range prev.Fields {
+		if def.Fields.ForName(pf.Name) == nil {
+			m.report(def.Name+"."+pf.Name, def.Position, pf.Position, "Field '%s.%s' is not defined by all definitions of type '%s'", def.Name, pf.Name, def.Name)
+		}
+	}
+
+	for _, v := range symmetricDifference(enumValueNames(def.EnumValues), enumValueNames(prev.EnumValues)) {
+		m.report(def.Name+"."+v, def.Position, prev.Position, "Enum value '%s.%s' is not defined by all definitions of enum '%s'", def.Name, v, def.Name)
+	}
+	for _, t := range symmetricDifference(def.Types, prev.Types) {
+		m.report(def.Name, def.Position, prev.Position, "Type '%s' is not a member of all definitions of union '%s'", t, def.Name)
+	}
+	for _, i := range symmetricDifference(def.Interfaces, prev.Interfaces) {
+		m.report(def.Name, def.Position, prev.Position, "Interface '%s' is not implemented by all definitions of type '%s'", i, def.Name)
+	}
+	if !sameDirectives(prev.Directives, def.Directives) {
+		m.report(def.Name, def.Position, prev.Position, "Type '%s' is defined with different directives", def.Name)
+	}
+}
+
+func (m *merger) mergeExtension(ext *ast.Definition) {
+	def, ok := m.types[ext.Name]
+	if !ok {
+		m.report(ext.Name, ext.Position, nil, "Type '%s' is extended but never defined", ext.Name)
+		return
+	}
+	if def.Kind != ext.Kind {
+		m.report(ext.Name, ext.Position, def.Position, "Type '%s' is defined as %s but extended as %s", ext.Name, def.Kind, ext.Kind)
+		return
+	}
+
+	for _, f := range ext.Fields {
+		switch prev := def.Fields.ForName(f.Name); {
+		case prev == nil:
+			def.Fields = append(def.Fields, f)
+		case !sameField(prev, f):
+			m.report(ext.Name+"."+f.Name, f.Position, prev.Position, "Field '%s.%s' is defined differently", ext.Name, f.Name)
+		}
+	}
+	for _, v := range ext.EnumValues {
+		if def.EnumValues.ForName(v.Name) == nil {
+			def.EnumValues = append(def.EnumValues, v)
+		}
+	}
+	def.Types = append(def.Types, difference(ext.Types, def.Types)...)
+	def.Interfaces = append(def.Interfaces, difference(ext.Interfaces, def.Interfaces)...)
+	def.Directives = m.mergeDirectives(ext.Name, def.Directives, ext.Directives)
+}
+
+// mergeDirectives appends the directives which are not applied yet.
+func (m *merger) mergeDirectives(path string, list, other ast.DirectiveList) ast.DirectiveList {
+	for _, d := range other {
+		prev := list.ForName(d.Name)
+		switch {
+		case prev == nil:
+			list = append(list, d)
+		case directiveString(prev) != directiveString(d):
+			m.report(path, d.Position, prev.Position, "Directive '@%s' is applied to '%s' with different arguments", d.Name, path)
+		}
+	}
+	return list
+}
+
+func copyDefinition(def *ast.Definition) *ast.Definition {
+	cp := *def
+	cp.Directives = append(ast.DirectiveList(nil), def.Directives...)
+	cp.Interfaces = append([]string(nil), def.Interfaces...)
+	cp.Fields = append(ast.FieldList(nil), def.Fields...)
+	cp.Types = append([]string(nil), def.Types...)
+	cp.EnumValues = append(ast.EnumValueList(nil), def.EnumValues...)
+	return &cp
+}
+
+func sameField(x, y *ast.FieldDefinition) bool {
+	return x.Type.String() == y.Type.String() &&
+		x.DefaultValue.String() == y.DefaultValue.String() &&
+		sameArguments(x.Arguments, y.Arguments) &&
+		sameDirectives(x.Directives, y.Directives)
+}
+
+func sameArguments(x, y ast.ArgumentDefinitionList) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for _, a := range x {
+		b := y.ForName(a.Name)
+		if b == nil ||
+			a.Type.String() != b.Type.String() ||
+			a.DefaultValue.String() != b.DefaultValue.String() ||
+			!sameDirectives(a.Directives, b.Directives) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameDirectives(x, y ast.DirectiveList) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for _, d := range x {
+		if o := y.ForName(d.Name); o == nil || directiveString(o) != directiveString(d) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameLocations(x, y []ast.DirectiveLocation) bool {
+	var xs, ys []string
+	for _, l := range x {
+		xs = append(xs, string(l))
+	}
+	for _, l := range y {
+		ys = append(ys, string(l))
+	}
+	return len(symmetricDifference(xs, ys)) == 0
+}
+
+func directiveString(d *ast.Directive) string {
+	args := make([]string, len(d.Arguments))
+	for i, a := range d.Arguments {
+		args[i] = a.Name + ":" + a.Value.String()
+	}
+	return "@" + d.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+func enumValueNames(list ast.EnumValueList) []string {
+	names := make([]string, len(list))
+	for i, v := range list {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// difference returns the names of x missing in y.
+func difference(x, y []string) []string {
+	var diff []string
+	for _, s := range x {
+		found := false
+		for _, o := range y {
+			if s == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, s)
+		}
+	}
+	return diff
+}
+
+func symmetricDifference(x, y []string) []string {
+	return append(difference(x, y), difference(y, x)...)
+}
@@ -0,0 +1,472 @@
+package merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// ThreeWay merges the changes made to the base schema by two sides, ours and theirs.
+// Changes of different items are all applied, changes of the same item are accepted only if both sides
+// made the same one, the others are reported as conflicts described by the changes detected by compare.
+// Items are root operation types, directive definitions, types, fields, arguments, enum values,
+// union members and implemented interfaces. Our version of a conflicting item is kept.
+// Changes are detected by comparing the versions of each item rather than by applying the results of compare:
+// those carry messages, not the new versions of the items, and omit changes such as deprecations.
+func ThreeWay(base, ours, theirs *ast.SchemaDocument) (*ast.SchemaDocument, []Conflict, error) {
+	var docs [3]*ast.SchemaDocument
+	for i, doc := range []*ast.SchemaDocument{base, ours, theirs} {
+		merged, conflicts := Documents(doc)
+		if len(conflicts) > 0 {
+			return nil, conflicts, nil
+		}
+		docs[i] = merged
+	}
+
+	m := &merger3{doc: &ast.SchemaDocument{Position: ours.Position}}
+	for i, side := range []*ast.SchemaDocument{docs[1], docs[2]} {
+		res, err := compare.Documents(docs[0], side)
+		if err != nil {
+			return nil, nil, err
+		}
+		m.changes[i] = make(map[string][]string)
+		for _, c := range res.Changes() {
+			m.changes[i][c.Path] = append(m.changes[i][c.Path], c.Message)
+		}
+	}
+
+	m.mergeSchema(docs[0], docs[1], docs[2])
+	m.mergeDirectiveDefinitions(docs[0].Directives, docs[1].Directives, docs[2].Directives)
+	m.mergeDefinitions(docs[0].Definitions, docs[1].Definitions, docs[2].Definitions)
+	return m.doc, m.conflicts, nil
+}
+
+type merger3 struct {
+	doc       *ast.SchemaDocument
+	changes   [2]map[string][]string // messages of our and their changes by path
+	conflicts []Conflict
+}
+
+const (
+	takeOurs = iota
+	takeTheirs
+	conflicting
+)
+
+// resolve decides which version of an item to take given their signatures, empty for missing items.
+func resolve(base, ours, theirs string) int {
+	switch {
+	case ours == theirs || theirs == base:
+		return takeOurs
+	case ours == base:
+		return takeTheirs
+	default:
+		return conflicting
+	}
+}
+
+func (m *merger3) report(path string, pos *ast.Position, base, ours, theirs string) {
+	describe := func(side int, sig string) string {
+		var s string
+		switch {
+		case base == "":
+			s = "added it"
+		case sig == "":
+			s = "removed it"
+		default:
+			s = "changed it"
+		}
+		if msgs := m.changes[side][strings.TrimPrefix(path, "@")]; len(msgs) > 0 {
+			s += " (" + strings.Join(msgs, "; ") + ")"
+		}
+		return s
+	}
+
+	m.conflicts = append(m.conflicts, Conflict{
+		Message:  fmt.Sprintf("Conflicting changes of '%s': ours %s, theirs %s", path, describe(0, ours), describe(1, theirs)),
+		Path:     path,
+		Position: pos,
+	})
+}
+
+func (m *merger3) mergeSchema(base, ours, theirs *ast.SchemaDocument) {
+	var defs [3]*ast.SchemaDefinition
+	for i, doc := range []*ast.SchemaDocument{base, ours, theirs} {
+		if len(doc.Schema) > 0 {
+			defs[i] = doc.Schema[0]
+		}
+	}
+	if defs[1] == nil && defs[2] == nil {
+		return
+	}
+
+	def := &ast.SchemaDefinition{}
+	switch b, o, t := schemaSig(defs[0]), schemaSig(defs[1]), schemaSig(defs[2]); resolve(b, o, t) {
+	case conflicting:
+		m.report("schema", position(defs[1], defs[2]), b, o, t)
+		fallthrough
+	case takeOurs:
+		if defs[1] != nil {
+			def.Description, def.Directives, def.Position = defs[1].Description, defs[1].Directives, defs[1].Position
+		}
+	case takeTheirs:
+		if defs[2] != nil {
+			def.Description, def.Directives, def.Position = defs[2].Description, defs[2].Directives, defs[2].Position
+		}
+	}
+	if def.Position == nil {
+		def.Position = position(defs[1], defs[2])
+	}
+
+	for _, op := range []ast.Operation{ast.Query, ast.Mutation, ast.Subscription} {
+		var ops [3]*ast.OperationTypeDefinition
+		var sigs [3]string
+		for i, d := range defs {
+			if d == nil {
+				continue
+			}
+			for _, o := range d.OperationTypes {
+				if o.Operation == op {
+					ops[i], sigs[i] = o, o.Type
+				}
+			}
+		}
+
+		chosen := ops[1]
+		switch resolve(sigs[0], sigs[1], sigs[2]) {
+		case takeTheirs:
+			chosen = ops[2]
+		case conflicting:
+			m.report("schema."+string(op), position(ops[1], ops[2]), sigs[0], sigs[1], sigs[2])
+		}
+		if chosen != nil {
+			def.OperationTypes = append(def.OperationTypes, chosen)
+		}
+	}
+
+	m.doc.Schema = append(m.doc.Schema, def)
+}
+
+func (m *merger3) mergeDirectiveDefinitions(base, ours, theirs ast.DirectiveDefinitionList) {
+	find := func(list ast.DirectiveDefinitionList, name string) *ast.DirectiveDefinition {
+		for _, d := range list {
+			if d.Name == name {
+				return d
+			}
+		}
+		return nil
+	}
+
+	var names [3][]string
+	for i, list := range []ast.DirectiveDefinitionList{base, ours, theirs} {
+		for _, d := range list {
+			names[i] = append(names[i], d.Name)
+		}
+	}
+
+	for _, name := range union(names[1], names[2], names[0]) {
+		b, o, t := find(base, name), find(ours, name), find(theirs, name)
+		chosen := o
+		switch bs, os, ts := directiveDefinitionSig(b), directiveDefinitionSig(o), directiveDefinitionSig(t); resolve(bs, os, ts) {
+		case takeTheirs:
+			chosen = t
+		case conflicting:
+			m.report("@"+name, position(o, t), bs, os, ts)
+		}
+		if chosen != nil {
+			m.doc.Directives = append(m.doc.Directives, chosen)
+		}
+	}
+}
+
+func (m *merger3) mergeDefinitions(base, ours, theirs ast.DefinitionList) {
+	var names [3][]string
+	for i, list := range []ast.DefinitionList{base, ours, theirs} {
+		for _, def := range list {
+			names[i] = append(names[i], def.Name)
+		}
+	}
+
+	for _, name := range union(names[1], names[2], names[0]) {
+		b, o, t := base.ForName(name), ours.ForName(name), theirs.ForName(name)
+
+		// both sides keep the type, merge its items
+		if o != nil && t != nil && o.Kind == t.Kind && (b == nil || b.Kind == o.Kind) {
+			m.doc.Definitions = append(m.doc.Definitions, m.mergeDefinition(b, o, t))
+			continue
+		}
+
+		chosen := o
+		switch bs, os, ts := definitionSig(b), definitionSig(o), definitionSig(t); resolve(bs, os, ts) {
+		case takeTheirs:
+			chosen = t
+		case conflicting:
+			m.report(name, position(o, t), bs, os, ts)
+		}
+		if chosen != nil {
+			m.doc.Definitions = append(m.doc.Definitions, chosen)
+		}
+	}
+}
+
+func (m *merger3) mergeDefinition(b, o, t *ast.Definition) *ast.Definition {
+	bs, os, ts := definitionHeaderSig(b), definitionHeaderSig(o), definitionHeaderSig(t)
+	if b == nil {
+		b = &ast.Definition{}
+	}
+	def := copyDefinition(o)
+
+	switch resolve(bs, os, ts) {
+	case takeTheirs:
+		def.Description, def.Directives = t.Description, t.Directives
+	case conflicting:
+		m.report(o.Name, o.Position, bs, os, ts)
+	}
+
+	def.Interfaces = mergeNames(b.Interfaces, o.Interfaces, t.Interfaces)
+	def.Types = mergeNames(b.Types, o.Types, t.Types)
+	def.Fields = m.mergeFields(o.Name, b.Fields, o.Fields, t.Fields)
+	def.EnumValues = m.mergeEnumValues(o.Name, b.EnumValues, o.EnumValues, t.EnumValues)
+	return def
+}
+
+func (m *merger3) mergeFields(path string, base, ours, theirs ast.FieldList) ast.FieldList {
+	var names [3][]string
+	for i, list := range []ast.FieldList{base, ours, theirs} {
+		for _, f := range list {
+			names[i] = append(names[i], f.Name)
+		}
+	}
+
+	var fields ast.FieldList
+	for _, name := range union(names[1], names[2], names[0]) {
+		b, o, t := base.ForName(name), ours.ForName(name), theirs.ForName(name)
+
+		// both sides keep the field, merge its arguments
+		if o != nil && t != nil {
+			f := *o
+			switch bs, os, ts := fieldHeaderSig(b), fieldHeaderSig(o), fieldHeaderSig(t); resolve(bs, os, ts) {
+			case takeTheirs:
+				f.Description, f.Type, f.DefaultValue, f.Directives = t.Description, t.Type, t.DefaultValue, t.Directives
+			case conflicting:
+				m.report(path+"."+name, o.Position, bs, os, ts)
+			}
+			var args ast.ArgumentDefinitionList
+			if b != nil {
+				args = b.Arguments
+			}
+			f.Arguments = m.mergeArguments(path+"."+name, args, o.Arguments, t.Arguments)
+			fields = append(fields, &f)
+			continue
+		}
+
+		chosen := o
+		switch bs, os, ts := fieldSig(b), fieldSig(o), fieldSig(t); resolve(bs, os, ts) {
+		case takeTheirs:
+			chosen = t
+		case conflicting:
+			m.report(path+"."+name, position(o, t), bs, os, ts)
+		}
+		if chosen != nil {
+			fields = append(fields, chosen)
+		}
+	}
+	return fields
+}
+
+func (m *merger3) mergeArguments(path string, base, ours, theirs ast.ArgumentDefinitionList) ast.ArgumentDefinitionList {
+	var names [3][]string
+	for i, list := range []ast.ArgumentDefinitionList{base, ours, theirs} {
+		for _, a := range list {
+			names[i] = append(names[i], a.Name)
+		}
+	}
+
+	var args ast.ArgumentDefinitionList
+	for _, name := range union(names[1], names[2], names[0]) {
+		b, o, t := base.ForName(name), ours.ForName(name), theirs.ForName(name)
+		chosen := o
+		switch bs, os, ts := argumentSig(b), argumentSig(o), argumentSig(t); resolve(bs, os, ts) {
+		case takeTheirs:
+			chosen = t
+		case conflicting:
+			m.report(path+"."+name, position(o, t), bs, os, ts)
+		}
+		if chosen != nil {
+			args = append(args, chosen)
+		}
+	}
+	return args
+}
+
+func (m *merger3) mergeEnumValues(path string, base, ours, theirs ast.EnumValueList) ast.EnumValueList {
+	var values ast.EnumValueList
+	for _, name := range union(enumValueNames(ours), enumValueNames(theirs), enumValueNames(base)) {
+		b, o, t := base.ForName(name), ours.ForName(name), theirs.ForName(name)
+		chosen := o
+		switch bs, os, ts := enumValueSig(b), enumValueSig(o), enumValueSig(t); resolve(bs, os, ts) {
+		case takeTheirs:
+			chosen = t
+		case conflicting:
+			m.report(path+"."+name, position(o, t), bs, os, ts)
+		}
+		if chosen != nil {
+			values = append(values, chosen)
+		}
+	}
+	return values
+}
+
+// mergeNames merges sets of names, additions and removals of a name never conflict.
+func mergeNames(base, ours, theirs []string) []string {
+	var names []string
+	for _, name := range union(ours, theirs, base) {
+		var sigs [3]string
+		for i, list := range [][]string{base, ours, theirs} {
+			if len(difference([]string{name}, list)) == 0 {
+				sigs[i] = name
+			}
+		}
+		present := sigs[1] != ""
+		if resolve(sigs[0], sigs[1], sigs[2]) == takeTheirs {
+			present = sigs[2] != ""
+		}
+		if present {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// union returns the names of all lists in the order of their first occurrence.
+func union(lists ...[]string) []string {
+	var names []string
+	for _, list := range lists {
+		names = append(names, difference(list, names)...)
+	}
+	return names
+}
+
+// position returns the position of the first existing item.
+func position(items ...interface{}) *ast.Position {
+	for _, it := range items {
+		switch it := it.(type) {
+		case *ast.SchemaDefinition:
+			if it != nil {
+				return it.Position
+			}
+		case *ast.OperationTypeDefinition:
+			if it != nil {
+				return it.Position
+			}
+		case *ast.DirectiveDefinition:
+			if it != nil {
+				return it.Position
+			}
+		case *ast.Definition:
+			if it != nil {
+				return it.Position
+			}
+		case *ast.FieldDefinition:
+			if it != nil {
+				return it.Position
+			}
+		case *ast.ArgumentDefinition:
+			if it != nil {
+				return it.Position
+			}
+		case *ast.EnumValueDefinition:
+			if it != nil {
+				return it.Position
+			}
+		}
+	}
+	return nil
+}
+
+// Signatures identify the versions of items, they are empty for missing items.
+
+func schemaSig(def *ast.SchemaDefinition) string {
+	if def == nil {
+		return ""
+	}
+	return fmt.Sprintf("schema %q %s", def.Description, directivesSig(def.Directives))
+}
+
+func directiveDefinitionSig(def *ast.DirectiveDefinition) string {
+	if def == nil {
+		return ""
+	}
+	locations := make([]string, len(def.Locations))
+	for i, l := range def.Locations {
+		locations[i] = string(l)
+	}
+	return fmt.Sprintf("@%s %q %s on %s", def.Name, def.Description, argumentsSig(def.Arguments), strings.Join(locations, "|"))
+}
+
+func definitionHeaderSig(def *ast.Definition) string {
+	if def == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %q %s", def.Kind, def.Description, directivesSig(def.Directives))
+}
+
+func definitionSig(def *ast.Definition) string {
+	if def == nil {
+		return ""
+	}
+	sig := definitionHeaderSig(def) + " implements " + strings.Join(def.Interfaces, "&") + " = " + strings.Join(def.Types, "|")
+	for _, f := range def.Fields {
+		sig += " " + f.Name + ":" + fieldSig(f)
+	}
+	for _, v := range def.EnumValues {
+		sig += " " + v.Name + ":" + enumValueSig(v)
+	}
+	return sig
+}
+
+func fieldHeaderSig(f *ast.FieldDefinition) string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprintf("%q %s = %s %s", f.Description, f.Type, f.DefaultValue, directivesSig(f.Directives))
+}
+
+func fieldSig(f *ast.FieldDefinition) string {
+	if f == nil {
+		return ""
+	}
+	return fieldHeaderSig(f) + " " + argumentsSig(f.Arguments)
+}
+
+func argumentsSig(args ast.ArgumentDefinitionList) string {
+	var sig string
+	for _, a := range args {
+		sig += a.Name + ":" + argumentSig(a) + " "
+	}
+	return "(" + sig + ")"
+}
+
+func argumentSig(a *ast.ArgumentDefinition) string {
+	if a == nil {
+		return ""
+	}
+	return fmt.Sprintf("%q %s = %s %s", a.Description, a.Type, a.DefaultValue, directivesSig(a.Directives))
+}
+
+func enumValueSig(v *ast.EnumValueDefinition) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%q %s", v.Description, directivesSig(v.Directives))
+}
+
+func directivesSig(list ast.DirectiveList) string {
+	sigs := make([]string, len(list))
+	for i, d := range list {
+		sigs[i] = directiveString(d)
+	}
+	return strings.Join(sigs, " ")
+}
@@ -0,0 +1,281 @@
+package merge
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestDocuments(t *testing.T) {
+	testData := []struct {
+		name      string
+		schemas   []string
+		want      string
+		conflicts []string
+	}{
+		{
+			name: "Extensions are applied",
+			schemas: []string{
+				`
+schema { query: Query }
+type Query { hero: Hero }
+type Hero { name: String }
+enum Episode { NEW_HOPE }
+`,
+				`
+extend schema { mutation: Mutation }
+extend type Query { droid: Droid }
+extend enum Episode { EMPIRE }
+type Droid { name: String }
+type Mutation { addHero: Hero }
+`,
+			},
+			want: `schema {
+  query: Query
+  mutation: Mutation
+}
+
+type Query {
+  hero: Hero
+  droid: Droid
+}
+
+type Hero {
+  name: String
+}
+
+enum Episode {
+  NEW_HOPE
+  EMPIRE
+}
+
+type Droid {
+  name: String
+}
+
+type Mutation {
+  addHero: Hero
+}
+`,
+		},
+		{
+			name: "Identical definitions are deduplicated",
+			schemas: []string{
+				`directive @key(fields: String!) on OBJECT scalar Time "Page" type Page { first: Int after: String }`,
+				`directive @key(fields: String!) on OBJECT scalar Time type Page { after: String first: Int }`,
+			},
+			want: `directive @key(fields: String!) on OBJECT
+
+scalar Time
+
+"""Page"""
+type Page {
+  first: Int
+  after: String
+}
+`,
+		},
+		{
+			name: "Conflicting definitions are reported",
+			schemas: []string{
+				`schema { query: Query } type Query { a: Int } type Hero { name: String } enum E { A }`,
+				`schema { query: Root } interface Query { a: Int } type Hero { name: String! } enum E { B }`,
+				`extend type Droid { name: String } extend type Hero { name: Int }`,
+			},
+			conflicts: []string{
+				"b.graphql:1:10: Root operation type 'query' is defined as 'Query' and 'Root' (previously defined at a.graphql:1:10)",
+				"b.graphql:1:34: Type 'Query' is defined as OBJECT and INTERFACE (previously defined at a.graphql:1:30)",
+				"b.graphql:1:63: Field 'Hero.name' is defined differently (previously defined at a.graphql:1:59)",
+				"b.graphql:1:84: Enum value 'E.B' is not defined by all definitions of enum 'E' (previously defined at a.graphql:1:79)",
+				"b.graphql:1:84: Enum value 'E.A' is not defined by all definitions of enum 'E' (previously defined at a.graphql:1:79)",
+				"c.graphql:1:13: Type 'Droid' is extended but never defined",
+				"c.graphql:1:55: Field 'Hero.name' is defined differently (previously defined at a.graphql:1:59)",
+			},
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			var docs []*ast.SchemaDocument
+			for i, sdl := range s.schemas {
+				doc, err := parser.ParseSchema(&ast.Source{Name: fmt.Sprintf("%c.graphql", 'a'+i), Input: sdl})
+				if err != nil {
+					t.Fatalf("unable to parse schema: %v", err)
+				}
+				docs = append(docs, doc)
+			}
+
+			doc, conflicts := Documents(docs...)
+
+			var have []string
+			for _, c := range conflicts {
+				have = append(have, c.Error())
+			}
+			if !reflect.DeepEqual(s.conflicts, have) {
+				t.Fatalf("invalid conflicts:\nwant %q\nhave %q", s.conflicts, have)
+			}
+			if len(conflicts) > 0 {
+				return
+			}
+
+			var buf bytes.Buffer
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				t.Fatalf("unable to print schema: %v", err)
+			}
+			if s.want != buf.String() {
+				t.Errorf("invalid schema:\nwant:\n%s\nhave:\n%s", s.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestThreeWay(t *testing.T) {
+	testData := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts []string
+	}{
+		{
+			name: "Changes of both sides are applied",
+			base: `
+type Query { hero(id: ID): Hero droid: Droid }
+type Hero { name: String }
+type Droid { name: String }
+enum Episode { NEW_HOPE }
+`,
+			ours: `
+type Query { hero(id: ID, episode: Episode): Hero droid: Droid }
+type Hero implements Node { id: ID! name: String }
+type Droid { name: String }
+enum Episode { NEW_HOPE }
+interface Node { id: ID! }
+`,
+			theirs: `
+type Query { hero(id: ID!): Hero }
+"Hero"
+type Hero { name: String friends: [Hero] }
+enum Episode { NEW_HOPE EMPIRE }
+`,
+			want: `type Query {
+  hero(id: ID!, episode: Episode): Hero
+}
+
+"""Hero"""
+type Hero implements Node {
+  id: ID!
+  name: String
+  friends: [Hero]
+}
+
+enum Episode {
+  NEW_HOPE
+  EMPIRE
+}
+
+interface Node {
+  id: ID!
+}
+`,
+		},
+		{
+			name:   "Same changes do not conflict",
+			base:   `type Query { a: Int }`,
+			ours:   `type Query { a: Int b: String }`,
+			theirs: `type Query { a: Int b: String }`,
+			want: `type Query {
+  a: Int
+  b: String
+}
+`,
+		},
+		{
+			name: "Different changes of the same item conflict",
+			base: `
+type Query { hero: Hero droid: Droid }
+type Hero { name: String }
+type Droid { name: String }
+`,
+			ours: `
+type Query { hero: Character droid: Droid }
+type Hero { name: String }
+type Droid { name: String! }
+`,
+			theirs: `
+type Query { hero: Human }
+type Hero { name: String }
+`,
+			conflicts: []string{
+				"ours.graphql:2:14: Conflicting changes of 'Query.hero': ours changed it (Field 'hero.Query' changed type from 'Hero' to 'Character'), theirs changed it (Field 'hero.Query' changed type from 'Hero' to 'Human')",
+				"ours.graphql:4:6: Conflicting changes of 'Droid': ours changed it, theirs removed it (Type 'Droid' was removed)",
+			},
+		},
+		{
+			name:   "Same change of an argument default does not conflict",
+			base:   `type Query { heroes(first: Int = 10): [String] }`,
+			ours:   `type Query { heroes(first: Int = 20): [String] }`,
+			theirs: `type Query { heroes(first: Int = 20): [String] }`,
+			want: `type Query {
+  heroes(first: Int = 20): [String]
+}
+`,
+		},
+		{
+			name:   "Deprecation of a removed field conflicts",
+			base:   `type Query { a: Int b: Int }`,
+			ours:   `type Query { a: Int b: Int @deprecated }`,
+			theirs: `type Query { a: Int }`,
+			conflicts: []string{
+				"ours.graphql:1:21: Conflicting changes of 'Query.b': ours changed it, theirs removed it (Field 'b' was removed from type 'Query')",
+			},
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			var docs []*ast.SchemaDocument
+			for _, src := range []*ast.Source{
+				{Name: "base.graphql", Input: s.base},
+				{Name: "ours.graphql", Input: s.ours},
+				{Name: "theirs.graphql", Input: s.theirs},
+			} {
+				doc, err := parser.ParseSchema(src)
+				if err != nil {
+					t.Fatalf("unable to parse schema: %v", err)
+				}
+				docs = append(docs, doc)
+			}
+
+			doc, conflicts, err := ThreeWay(docs[0], docs[1], docs[2])
+			if err != nil {
+				t.Fatalf("unable to merge schemas: %v", err)
+			}
+
+			var have []string
+			for _, c := range conflicts {
+				have = append(have, c.Error())
+			}
+			if !reflect.DeepEqual(s.conflicts, have) {
+				t.Fatalf("invalid conflicts:\nwant %q\nhave %q", s.conflicts, have)
+			}
+			if len(conflicts) > 0 {
+				return
+			}
+
+			var buf bytes.Buffer
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				t.Fatalf("unable to print schema: %v", err)
+			}
+			if s.want != buf.String() {
+				t.Errorf("invalid schema:\nwant:\n%s\nhave:\n%s", s.want, buf.String())
+			}
+		})
+	}
+}
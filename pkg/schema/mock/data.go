@@ -0,0 +1,160 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// generated is a value to be generated, the path of the field it is the value of seeds the generator.
+type generated struct {
+	path  string
+	field string
+}
+
+func (g generated) child(field string, args map[string]interface{}) generated {
+	path := g.path + "." + field
+	if len(args) > 0 {
+		b, _ := json.Marshal(args)
+		path += string(b)
+	}
+	return generated{path: path, field: field}
+}
+
+func (g generated) item(i int) generated {
+	return generated{path: g.path + "." + strconv.Itoa(i), field: g.field}
+}
+
+func (g generated) rand() *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(g.path))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// leaf generates a value of the scalar or enum type, the values configured for the scalar take precedence.
+func (s *Server) leaf(def *ast.Definition, g generated) interface{} {
+	r := g.rand()
+	if values := s.cfg.Scalars[def.Name]; len(values) > 0 {
+		return values[r.Intn(len(values))]
+	}
+
+	switch def.Kind {
+	case ast.Enum:
+		if len(def.EnumValues) == 0 {
+			return nil
+		}
+		return def.EnumValues[r.Intn(len(def.EnumValues))].Name
+	}
+	switch def.Name {
+	case "Int":
+		return r.Intn(100)
+	case "Float":
+		return float64(r.Intn(10000)) / 100
+	case "Boolean":
+		return r.Intn(2) == 0
+	case "ID":
+		return strconv.Itoa(r.Intn(1000000))
+	case "String":
+		return fmt.Sprintf("%s %d", g.field, r.Intn(100))
+	default:
+		return fmt.Sprintf("%s %d", def.Name, r.Intn(100))
+	}
+}
+
+// introspectionData converts the introspection schema into the data the introspection fields resolve to:
+// the type references are replaced by the types they refer to and the fields missing in the query result are added.
+func introspectionData(s *introspection.Schema) (map[string]interface{}, map[string]map[string]interface{}, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		return nil, nil, err
+	}
+
+	types := make(map[string]map[string]interface{})
+	for _, t := range list(schema["types"]) {
+		t := t.(map[string]interface{})
+		types[t["name"].(string)] = t
+	}
+
+	var ref func(v interface{}) interface{}
+	ref = func(v interface{}) interface{} {
+		r, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if name, ok := r["name"].(string); ok {
+			if t, ok := types[name]; ok {
+				return t
+			}
+		}
+		return map[string]interface{}{
+			"kind":          r["kind"],
+			"name":          nil,
+			"description":   nil,
+			"ofType":        ref(r["ofType"]),
+			"fields":        nil,
+			"interfaces":    nil,
+			"possibleTypes": nil,
+			"enumValues":    nil,
+			"inputFields":   nil,
+		}
+	}
+	inputValues := func(v interface{}) {
+		for _, iv := range list(v) {
+			iv := iv.(map[string]interface{})
+			iv["type"] = ref(iv["type"])
+			iv["isDeprecated"] = false
+			iv["deprecationReason"] = nil
+		}
+	}
+	refs := func(v interface{}) interface{} {
+		if v == nil {
+			return nil
+		}
+		res := []interface{}{}
+		for _, r := range list(v) {
+			res = append(res, ref(r))
+		}
+		return res
+	}
+
+	for _, t := range types {
+		for _, key := range []string{"description", "specifiedByURL", "fields", "interfaces", "possibleTypes", "enumValues", "inputFields"} {
+			if _, ok := t[key]; !ok {
+				t[key] = nil
+			}
+		}
+		t["ofType"] = nil
+		for _, f := range list(t["fields"]) {
+			f := f.(map[string]interface{})
+			f["type"] = ref(f["type"])
+			inputValues(f["args"])
+		}
+		inputValues(t["inputFields"])
+		t["interfaces"] = refs(t["interfaces"])
+		t["possibleTypes"] = refs(t["possibleTypes"])
+	}
+	for _, d := range list(schema["directives"]) {
+		d := d.(map[string]interface{})
+		d["isRepeatable"] = false
+		inputValues(d["args"])
+	}
+	schema["description"] = nil
+	for _, key := range []string{"queryType", "mutationType", "subscriptionType"} {
+		schema[key] = ref(schema[key])
+	}
+	return schema, types, nil
+}
+
+func list(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
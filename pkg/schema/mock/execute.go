@@ -0,0 +1,289 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+	"github.com/vektah/gqlparser/parser"
+	"github.com/vektah/gqlparser/validator"
+
+	// registers the validation rules of the specification
+	_ "github.com/vektah/gqlparser/validator/rules"
+)
+
+type executor struct {
+	s        *Server
+	doc      *ast.QueryDocument
+	vars     map[string]interface{}
+	readOnly bool
+}
+
+func newExecutor(s *Server) *executor {
+	return &executor{s: s}
+}
+
+func (e *executor) execute(req Request) *Response {
+	doc, gqlErr := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if gqlErr != nil {
+		return &Response{Errors: gqlerror.List{gqlErr}}
+	}
+	if errs := validator.Validate(e.s.schema, doc); len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+	e.doc = doc
+
+	op, err := e.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: gqlerror.List{err}}
+	}
+	var root *ast.Definition
+	switch op.Operation {
+	case ast.Query:
+		root = e.s.schema.Query
+	case ast.Mutation:
+		if e.readOnly {
+			return &Response{Errors: gqlerror.List{gqlerror.Errorf("mutations are not allowed using GET")}}
+		}
+		root = e.s.schema.Mutation
+	default:
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("%s operations are not supported", op.Operation)}}
+	}
+
+	vars, gqlErr := validator.VariableValues(e.s.schema, op, req.Variables)
+	if gqlErr != nil {
+		return &Response{Errors: gqlerror.List{gqlErr}}
+	}
+	e.vars = vars
+
+	return &Response{Data: e.executeFields(root, generated{}, e.collectFields(root, op.SelectionSet, nil))}
+}
+
+func (e *executor) operation(name string) (*ast.OperationDefinition, *gqlerror.Error) {
+	if name != "" {
+		op := e.doc.Operations.ForName(name)
+		if op == nil {
+			return nil, gqlerror.Errorf("unknown operation '%s'", name)
+		}
+		return op, nil
+	}
+	if len(e.doc.Operations) != 1 {
+		return nil, gqlerror.Errorf("operation name is required when the document contains several operations")
+	}
+	return e.doc.Operations[0], nil
+}
+
+// fieldGroup holds the fields selected under the same response key, they are executed as one.
+type fieldGroup struct {
+	key    string
+	fields []*ast.Field
+}
+
+// collectFields groups the fields selected on the object type by response key in the order of selection,
+// the fragments applying to the type are expanded and the skip and include directives honored.
+func (e *executor) collectFields(def *ast.Definition, set ast.SelectionSet, groups []fieldGroup) []fieldGroup {
+	visited := make(map[string]bool)
+	var collect func(set ast.SelectionSet)
+	collect = func(set ast.SelectionSet) {
+		for _, sel := range set {
+			switch sel := sel.(type) {
+			case *ast.Field:
+				if !e.included(sel.Directives) {
+					continue
+				}
+				key := sel.Alias
+				if key == "" {
+					key = sel.Name
+				}
+				found := false
+				for i := range groups {
+					if groups[i].key == key {
+						groups[i].fields = append(groups[i].fields, sel)
+						found = true
+						break
+					}
+				}
+				if !found {
+					groups = append(groups, fieldGroup{key: key, fields: []*ast.Field{sel}})
+				}
+			case *ast.InlineFragment:
+				if e.included(sel.Directives) && e.applies(def, sel.TypeCondition) {
+					collect(sel.SelectionSet)
+				}
+			case *ast.FragmentSpread:
+				if !e.included(sel.Directives) || visited[sel.Name] {
+					continue
+				}
+				visited[sel.Name] = true
+				f := e.doc.Fragments.ForName(sel.Name)
+				if f != nil && e.applies(def, f.TypeCondition) {
+					collect(f.SelectionSet)
+				}
+			}
+		}
+	}
+	collect(set)
+	return groups
+}
+
+func (e *executor) included(list ast.DirectiveList) bool {
+	if d := list.ForName("skip"); d != nil {
+		if skip, _ := d.ArgumentMap(e.vars)["if"].(bool); skip {
+			return false
+		}
+	}
+	if d := list.ForName("include"); d != nil {
+		if include, _ := d.ArgumentMap(e.vars)["if"].(bool); !include {
+			return false
+		}
+	}
+	return true
+}
+
+// applies tells whether a fragment with the type condition applies to the object type.
+func (e *executor) applies(def *ast.Definition, condition string) bool {
+	if condition == "" || condition == def.Name {
+		return true
+	}
+	for _, t := range e.s.schema.GetPossibleTypes(e.s.schema.Types[condition]) {
+		if t.Name == def.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *executor) executeFields(def *ast.Definition, source interface{}, groups []fieldGroup) object {
+	obj := make(object, 0, len(groups))
+	for _, g := range groups {
+		f := g.fields[0]
+		var value interface{}
+		if f.Name == "__typename" {
+			value = def.Name
+		} else if fd := def.Fields.ForName(f.Name); fd != nil {
+			value = e.complete(fd.Type, g.fields, e.resolve(def, source, f))
+		}
+		obj = append(obj, objectField{key: g.key, value: value})
+	}
+	return obj
+}
+
+// resolve returns the value of the field, either generated or read from the introspection data.
+func (e *executor) resolve(def *ast.Definition, source interface{}, f *ast.Field) interface{} {
+	args := f.ArgumentMap(e.vars)
+	switch source := source.(type) {
+	case generated:
+		if def == e.s.schema.Query {
+			switch f.Name {
+			case "__schema":
+				return e.s.introspection
+			case "__type":
+				name, _ := args["name"].(string)
+				if t, ok := e.s.types[name]; ok {
+					return t
+				}
+				return nil
+			}
+		}
+		return source.child(f.Name, args)
+	case map[string]interface{}:
+		v := source[f.Name]
+		if list, ok := v.([]interface{}); ok && (f.Name == "fields" || f.Name == "enumValues") {
+			if include, _ := args["includeDeprecated"].(bool); !include {
+				return withoutDeprecated(list)
+			}
+		}
+		return v
+	}
+	return nil
+}
+
+func (e *executor) complete(typ *ast.Type, fields []*ast.Field, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	if typ.Elem != nil {
+		var items []interface{}
+		switch v := v.(type) {
+		case generated:
+			for i := 0; i < e.s.cfg.ListLength; i++ {
+				items = append(items, v.item(i))
+			}
+		case []interface{}:
+			items = v
+		default:
+			return nil
+		}
+		list := make([]interface{}, len(items))
+		for i, item := range items {
+			list[i] = e.complete(typ.Elem, fields, item)
+		}
+		return list
+	}
+
+	def := e.s.schema.Types[typ.NamedType]
+	switch def.Kind {
+	case ast.Scalar, ast.Enum:
+		if g, ok := v.(generated); ok {
+			return e.s.leaf(def, g)
+		}
+		return v
+	case ast.Interface, ast.Union:
+		g, ok := v.(generated)
+		possible := e.s.schema.GetPossibleTypes(def)
+		if !ok || len(possible) == 0 {
+			return nil
+		}
+		def = possible[g.rand().Intn(len(possible))]
+	}
+
+	var groups []fieldGroup
+	for _, f := range fields {
+		groups = e.collectFields(def, f.SelectionSet, groups)
+	}
+	return e.executeFields(def, v, groups)
+}
+
+func withoutDeprecated(list []interface{}) []interface{} {
+	res := []interface{}{}
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok && m["isDeprecated"] == true {
+			continue
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+// object is a JSON object keeping the order of the fields as selected.
+type object []objectField
+
+type objectField struct {
+	key   string
+	value interface{}
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %v", f.key, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,160 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+const defaultListLength = 2
+
+// Config customizes the generated data.
+type Config struct {
+
+	// Scalars maps scalar types to the values picked from for the fields of the type,
+	// built-in scalars may be overridden too
+	Scalars map[string][]interface{} `json:"scalars"`
+
+	// ListLength is the number of items of the generated lists, 2 unless set
+	ListLength int `json:"listLength"`
+}
+
+// LoadConfig reads the JSON encoded configuration, e.g. {"scalars": {"DateTime": ["2019-01-01T00:00:00Z"]}}.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+	if err := json.NewDecoder(r).Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("unable to read mock configuration: %v", err)
+	}
+	if cfg.ListLength < 0 {
+		return Config{}, fmt.Errorf("invalid list length %d", cfg.ListLength)
+	}
+	return cfg, nil
+}
+
+// Server executes GraphQL requests against a schema, the fields resolve to generated data.
+// The data is deterministic, the same field with the same arguments at the same path always resolves to the same value.
+type Server struct {
+	schema        *ast.Schema
+	cfg           Config
+	introspection map[string]interface{}
+	types         map[string]map[string]interface{}
+}
+
+// New creates a server of the schema document, the built-in scalars, directives and introspection types are added.
+func New(doc *ast.SchemaDocument, cfg Config) (*Server, error) {
+	if cfg.ListLength == 0 {
+		cfg.ListLength = defaultListLength
+	}
+
+	schema, err := introspection.LoadSchema(doc)
+	if err != nil {
+		return nil, err
+	}
+	for name := range cfg.Scalars {
+		if def := schema.Types[name]; def == nil || def.Kind != ast.Scalar {
+			return nil, fmt.Errorf("scalar '%s' not found", name)
+		}
+	}
+
+	s, err := introspection.FromDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	schemaValue, types, err := introspectionData(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		schema:        schema,
+		cfg:           cfg,
+		introspection: schemaValue,
+		types:         types,
+	}, nil
+}
+
+// Response is the result of a request. Data is left out when the request fails before the execution starts.
+type Response struct {
+	Data   interface{}   `json:"data,omitempty"`
+	Errors gqlerror.List `json:"errors,omitempty"`
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Execute validates and executes the request. Subscriptions are not supported.
+func (s *Server) Execute(req Request) *Response {
+	return newExecutor(s).execute(req)
+}
+
+// ServeHTTP serves the requests sent either using GET with the query string parameters or using POST
+// with a JSON body, or with the query as the body when the content type is application/graphql.
+// Mutations are accepted only using POST. Any origin is allowed to send requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	var req Request
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid variables: %v", err))
+				return
+			}
+		}
+	case http.MethodPost:
+		ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if ct == "application/graphql" {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			req.Query = string(b)
+		} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, OPTIONS")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method '%s' not allowed", r.Method))
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "missing query")
+		return
+	}
+
+	e := newExecutor(s)
+	e.readOnly = r.Method == http.MethodGet
+	writeResponse(w, http.StatusOK, e.execute(req))
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeResponse(w, status, &Response{Errors: gqlerror.List{{Message: message}}})
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp *Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
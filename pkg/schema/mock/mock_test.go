@@ -0,0 +1,205 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const schemaSDL = `
+scalar DateTime
+
+enum Role {
+  ADMIN
+  GUEST @deprecated
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String!
+  role: Role
+  created: DateTime
+  friends: [User!]!
+}
+
+union Item = User
+
+type Query {
+  me: User
+  node(id: ID!): Node
+  items: [Item]
+}
+
+type Mutation {
+  rename(name: String!): User
+}
+`
+
+func newServer(t *testing.T) *Server {
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaSDL})
+	if gqlErr != nil {
+		t.Fatal(gqlErr)
+	}
+	s, err := New(doc, Config{
+		Scalars: map[string][]interface{}{
+			"ID":       {"1"},
+			"String":   {"Luke"},
+			"DateTime": {"2019-01-01T00:00:00Z"},
+		},
+		ListLength: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestExecute(t *testing.T) {
+	testData := []struct {
+		name string
+		req  Request
+		want string
+	}{
+		{
+			name: "fields",
+			req:  Request{Query: `{ me { id name created } }`},
+			want: `{"data":{"me":{"id":"1","name":"Luke","created":"2019-01-01T00:00:00Z"}}}`,
+		},
+		{
+			name: "aliases and lists",
+			req:  Request{Query: `{ me { friends { a: id b: name } } }`},
+			want: `{"data":{"me":{"friends":[{"a":"1","b":"Luke"}]}}}`,
+		},
+		{
+			name: "enum",
+			req:  Request{Query: `{ me { role } }`},
+			want: `{"data":{"me":{"role":"ADMIN"}}}`,
+		},
+		{
+			name: "fragments",
+			req:  Request{Query: `{ node(id: "1") { __typename ...F ... on User { name } } } fragment F on Node { id }`},
+			want: `{"data":{"node":{"__typename":"User","id":"1","name":"Luke"}}}`,
+		},
+		{
+			name: "union",
+			req:  Request{Query: `{ items { ... on User { id } } }`},
+			want: `{"data":{"items":[{"id":"1"}]}}`,
+		},
+		{
+			name: "skip and include",
+			req: Request{
+				Query:     `query ($x: Boolean!) { me { id @skip(if: $x) name @include(if: $x) } }`,
+				Variables: map[string]interface{}{"x": true},
+			},
+			want: `{"data":{"me":{"name":"Luke"}}}`,
+		},
+		{
+			name: "operation name",
+			req:  Request{Query: `query A { me { id } } mutation B { rename(name: "x") { name } }`, OperationName: "B"},
+			want: `{"data":{"rename":{"name":"Luke"}}}`,
+		},
+		{
+			name: "introspection",
+			req:  Request{Query: `{ __schema { queryType { name } } __type(name: "Role") { kind enumValues { name } } }`},
+			want: `{"data":{"__schema":{"queryType":{"name":"Query"}},"__type":{"kind":"ENUM","enumValues":[{"name":"ADMIN"}]}}}`,
+		},
+		{
+			name: "introspection of type references",
+			req:  Request{Query: `{ __type(name: "User") { fields(includeDeprecated: true) { name type { kind ofType { name } } } } }`},
+			want: `{"data":{"__type":{"fields":[` +
+				`{"name":"id","type":{"kind":"NON_NULL","ofType":{"name":"ID"}}},` +
+				`{"name":"name","type":{"kind":"NON_NULL","ofType":{"name":"String"}}},` +
+				`{"name":"role","type":{"kind":"ENUM","ofType":null}},` +
+				`{"name":"created","type":{"kind":"SCALAR","ofType":null}},` +
+				`{"name":"friends","type":{"kind":"NON_NULL","ofType":{"name":null}}}]}}}`,
+		},
+		{
+			name: "validation error",
+			req:  Request{Query: `{ unknown }`},
+			want: `{"errors":[{"message":"Cannot query field \"unknown\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name: "missing operation name",
+			req:  Request{Query: `query A { me { id } } query B { me { id } }`},
+			want: `{"errors":[{"message":"operation name is required when the document contains several operations"}]}`,
+		},
+	}
+
+	s := newServer(t)
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(s.Execute(tt.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(b); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteDeterministic(t *testing.T) {
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Input: schemaSDL})
+	if gqlErr != nil {
+		t.Fatal(gqlErr)
+	}
+	s, err := New(doc, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := Request{Query: `{ me { id name role friends { id } } }`}
+	x, _ := json.Marshal(s.Execute(req))
+	y, _ := json.Marshal(s.Execute(req))
+	if string(x) != string(y) {
+		t.Errorf("got %s and %s, want the same data", x, y)
+	}
+	if strings.Count(string(x), `"id"`) != 3 {
+		t.Errorf("got %s, want 2 friends", x)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	testData := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		ctype  string
+		status int
+	}{
+		{name: "post", method: http.MethodPost, target: "/", body: `{"query": "{ me { id } }"}`, ctype: "application/json", status: http.StatusOK},
+		{name: "post graphql", method: http.MethodPost, target: "/", body: `{ me { id } }`, ctype: "application/graphql", status: http.StatusOK},
+		{name: "get", method: http.MethodGet, target: "/?query=" + url.QueryEscape(`{ me { id } }`), status: http.StatusOK},
+		{name: "invalid body", method: http.MethodPost, target: "/", body: `{`, ctype: "application/json", status: http.StatusBadRequest},
+		{name: "missing query", method: http.MethodGet, target: "/", status: http.StatusBadRequest},
+		{name: "preflight", method: http.MethodOptions, target: "/", status: http.StatusNoContent},
+		{name: "method not allowed", method: http.MethodPut, target: "/", status: http.StatusMethodNotAllowed},
+	}
+
+	s := newServer(t)
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.ctype != "" {
+				req.Header.Set("Content-Type", tt.ctype)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("got status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,493 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+// Patch is a list of operations transforming one schema into another.
+// Each operation adds, removes or replaces a single item identified by its schema coordinate:
+//
+//	schema.query        root operation type
+//	@key                directive definition
+//	Hero                type, without its fields and enum values
+//	Hero.name           field, input field or enum value, with its arguments
+//
+// The items are provided as SDL, members of types wrapped in their (otherwise empty) type definition.
+type Patch struct {
+	Operations []Operation `json:"operations"`
+}
+
+// Operation kinds.
+const (
+	Add     = "add"
+	Remove  = "remove"
+	Replace = "replace"
+)
+
+// Operation is a change of a single item.
+type Operation struct {
+
+	// Op is one of add, remove or replace
+	Op string `json:"op"`
+
+	// Coordinate of the item
+	Coordinate string `json:"coordinate"`
+
+	// SDL of the new item, empty for removals
+	SDL string `json:"sdl,omitempty"`
+}
+
+// Read decodes a JSON encoded patch.
+func Read(r io.Reader) (*Patch, error) {
+	var p Patch
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("unable to read patch: %v", err)
+	}
+	for _, op := range p.Operations {
+		if op.Op != Add && op.Op != Remove && op.Op != Replace {
+			return nil, fmt.Errorf("invalid operation '%s' of '%s'", op.Op, op.Coordinate)
+		}
+	}
+	return &p, nil
+}
+
+// Create computes the patch transforming schema x into schema y.
+// The operations are derived from the changes reported by compare.Documents, each change turns into an operation
+// on its item, so that the patch carries exactly the changes compare reports: those compare does not detect,
+// e.g. of deprecations or applied directives, are left out. Items of added types and root operation types
+// referring to them are added along with the types, types changing their kind are removed and added again.
+// Removals come first, additions and replacements follow in the order of schema y.
+func Create(x, y *ast.SchemaDocument) (*Patch, error) {
+	x, conflicts := merge.Documents(x)
+	if len(conflicts) > 0 {
+		return nil, conflicts[0]
+	}
+	y, conflicts = merge.Documents(y)
+	if len(conflicts) > 0 {
+		return nil, conflicts[0]
+	}
+
+	res, err := compare.Documents(x, y)
+	if err != nil {
+		return nil, err
+	}
+	ix, err := items(x)
+	if err != nil {
+		return nil, err
+	}
+	iy, err := items(y)
+	if err != nil {
+		return nil, err
+	}
+
+	byCoordinate := func(list []item) map[string]item {
+		m := make(map[string]item)
+		for _, it := range list {
+			m[it.coordinate] = it
+		}
+		return m
+	}
+	mx, my := byCoordinate(ix), byCoordinate(iy)
+
+	changed := make(map[string]bool)
+	recreated := make(map[string]bool)
+	for _, c := range res.Changes() {
+		changed[coordinate(c)] = true
+		switch c.Type {
+		case compare.TypeKindChanged:
+			recreated[c.Path] = true
+		case compare.TypeAdded:
+			for _, op := range addedRoots(x, y, c.Path) {
+				changed["schema."+string(op)] = true
+			}
+		}
+	}
+
+	p := &Patch{}
+	for _, it := range ix {
+		if _, ok := my[it.coordinate]; changed[it.coordinate] && (!ok || recreated[it.coordinate]) && !removed(my, recreated, it.parent) {
+			p.Operations = append(p.Operations, Operation{Op: Remove, Coordinate: it.coordinate})
+		}
+	}
+	for _, it := range iy {
+		old, ok := mx[it.coordinate]
+		_, parentExisted := mx[it.parent]
+		switch {
+		case it.parent != "" && (!parentExisted || recreated[it.parent]):
+			p.Operations = append(p.Operations, Operation{Op: Add, Coordinate: it.coordinate, SDL: it.sdl})
+		case !changed[it.coordinate]:
+		case !ok || recreated[it.coordinate]:
+			p.Operations = append(p.Operations, Operation{Op: Add, Coordinate: it.coordinate, SDL: it.sdl})
+		case old.sdl != it.sdl:
+			p.Operations = append(p.Operations, Operation{Op: Replace, Coordinate: it.coordinate, SDL: it.sdl})
+		}
+	}
+	return p, nil
+}
+
+// rootChanges maps the changes of root operation types to the operations.
+var rootChanges = map[compare.ChangeType]ast.Operation{
+	compare.SchemaQueryTypeChanged:        ast.Query,
+	compare.SchemaMutationTypeChanged:     ast.Mutation,
+	compare.SchemaMutationTypeRemoved:     ast.Mutation,
+	compare.SchemaSubscriptionTypeChanged: ast.Subscription,
+	compare.SchemaSubscriptionTypeRemoved: ast.Subscription,
+}
+
+// coordinate returns the coordinate of the item the change is made to. Changes of arguments are changes
+// of their fields or directives.
+func coordinate(c compare.Change) string {
+	if op, ok := rootChanges[c.Type]; ok {
+		return "schema." + string(op)
+	}
+	parts := strings.Split(c.Path, ".")
+	if strings.HasPrefix(string(c.Type), "DIRECTIVE_") {
+		return "@" + parts[0]
+	}
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
+
+// addedRoots returns the root operation types of schema y referring to the type which schema x lacks.
+func addedRoots(x, y *ast.SchemaDocument, name string) []ast.Operation {
+	existing := make(map[ast.Operation]bool)
+	for _, def := range x.Schema {
+		for _, op := range def.OperationTypes {
+			existing[op.Operation] = true
+		}
+	}
+	var ops []ast.Operation
+	for _, def := range y.Schema {
+		for _, op := range def.OperationTypes {
+			if op.Type == name && !existing[op.Operation] {
+				ops = append(ops, op.Operation)
+			}
+		}
+	}
+	return ops
+}
+
+// removed tells whether the parent of an item is removed too.
+func removed(items map[string]item, recreated map[string]bool, parent string) bool {
+	if parent == "" {
+		return false
+	}
+	_, ok := items[parent]
+	return !ok || recreated[parent]
+}
+
+// item is a part of a schema a patch operates on.
+type item struct {
+	coordinate string
+	parent     string
+	kind       ast.DefinitionKind
+	sdl        string
+}
+
+// items splits the merged document into items.
+func items(doc *ast.SchemaDocument) ([]item, error) {
+	var items []item
+	add := func(coordinate, parent string, kind ast.DefinitionKind, doc *ast.SchemaDocument) error {
+		var buf bytes.Buffer
+		if err := format.Document(&buf, doc, format.Options{StripComments: true}); err != nil {
+			return err
+		}
+		items = append(items, item{coordinate: coordinate, parent: parent, kind: kind, sdl: buf.String()})
+		return nil
+	}
+
+	for _, def := range doc.Schema {
+		for _, op := range def.OperationTypes {
+			schema := &ast.SchemaDefinition{OperationTypes: ast.OperationTypeDefinitionList{op}}
+			if err := add("schema."+string(op.Operation), "", "", &ast.SchemaDocument{Schema: ast.SchemaDefinitionList{schema}}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, def := range doc.Directives {
+		if err := add("@"+def.Name, "", "", &ast.SchemaDocument{Directives: ast.DirectiveDefinitionList{def}}); err != nil {
+			return nil, err
+		}
+	}
+	for _, def := range doc.Definitions {
+		header := *def
+		header.Fields, header.EnumValues = nil, nil
+		if err := add(def.Name, "", def.Kind, &ast.SchemaDocument{Definitions: ast.DefinitionList{&header}}); err != nil {
+			return nil, err
+		}
+
+		for _, f := range def.Fields {
+			member := &ast.Definition{Kind: def.Kind, Name: def.Name, Fields: ast.FieldList{f}}
+			if err := add(def.Name+"."+f.Name, def.Name, def.Kind, &ast.SchemaDocument{Definitions: ast.DefinitionList{member}}); err != nil {
+				return nil, err
+			}
+		}
+		for _, v := range def.EnumValues {
+			member := &ast.Definition{Kind: def.Kind, Name: def.Name, EnumValues: ast.EnumValueList{v}}
+			if err := add(def.Name+"."+v.Name, def.Name, def.Kind, &ast.SchemaDocument{Definitions: ast.DefinitionList{member}}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return items, nil
+}
+
+// Apply replays the patch onto the schema and returns the patched schema, the given one is left intact.
+// Adding an item which already exists in the same form is not an error so that a patch can be applied
+// to a schema which already contains some of the changes.
+func Apply(doc *ast.SchemaDocument, p *Patch) (*ast.SchemaDocument, error) {
+	doc, conflicts := merge.Documents(doc)
+	if len(conflicts) > 0 {
+		return nil, conflicts[0]
+	}
+
+	for _, op := range p.Operations {
+		if err := apply(doc, op); err != nil {
+			return nil, fmt.Errorf("unable to %s '%s': %v", op.Op, op.Coordinate, err)
+		}
+	}
+	return doc, nil
+}
+
+func apply(doc *ast.SchemaDocument, op Operation) error {
+	var src *ast.SchemaDocument
+	if op.Op != Remove {
+		doc, gqlErr := parser.ParseSchema(&ast.Source{Name: op.Coordinate, Input: op.SDL})
+		if gqlErr != nil {
+			return gqlErr
+		}
+		src = doc
+	}
+
+	switch parts := strings.Split(op.Coordinate, "."); {
+	case parts[0] == "schema" && len(parts) == 2:
+		return applyOperationType(doc, op.Op, ast.Operation(parts[1]), src)
+	case strings.HasPrefix(parts[0], "@") && len(parts) == 1:
+		return applyDirectiveDefinition(doc, op.Op, parts[0][1:], src)
+	case len(parts) == 1:
+		return applyDefinition(doc, op.Op, parts[0], src)
+	case len(parts) == 2:
+		return applyMember(doc, op.Op, parts[0], parts[1], src)
+	default:
+		return fmt.Errorf("invalid coordinate")
+	}
+}
+
+func applyOperationType(doc *ast.SchemaDocument, op string, operation ast.Operation, src *ast.SchemaDocument) error {
+	if len(doc.Schema) == 0 {
+		doc.Schema = append(doc.Schema, &ast.SchemaDefinition{})
+	}
+	def := doc.Schema[0]
+
+	i := -1
+	for j, o := range def.OperationTypes {
+		if o.Operation == operation {
+			i = j
+		}
+	}
+
+	var value *ast.OperationTypeDefinition
+	if src != nil {
+		for _, s := range src.Schema {
+			for _, o := range s.OperationTypes {
+				if o.Operation == operation {
+					value = o
+				}
+			}
+		}
+		if value == nil {
+			return fmt.Errorf("SDL does not define root operation type '%s'", operation)
+		}
+	}
+
+	switch {
+	case op == Add && i < 0:
+		def.OperationTypes = append(def.OperationTypes, value)
+	case op == Add && def.OperationTypes[i].Type == value.Type:
+	case i < 0:
+		return fmt.Errorf("root operation type does not exist")
+	case op == Add:
+		return fmt.Errorf("root operation type already exists")
+	case op == Replace:
+		def.OperationTypes[i] = value
+	case op == Remove:
+		def.OperationTypes = append(def.OperationTypes[:i:i], def.OperationTypes[i+1:]...)
+		if len(def.OperationTypes) == 0 && len(def.Directives) == 0 {
+			doc.Schema = nil
+		}
+	}
+	return nil
+}
+
+func applyDirectiveDefinition(doc *ast.SchemaDocument, op, name string, src *ast.SchemaDocument) error {
+	i := -1
+	for j, d := range doc.Directives {
+		if d.Name == name {
+			i = j
+		}
+	}
+
+	var value *ast.DirectiveDefinition
+	if src != nil {
+		if len(src.Directives) != 1 || src.Directives[0].Name != name {
+			return fmt.Errorf("SDL does not define directive '@%s'", name)
+		}
+		value = src.Directives[0]
+	}
+
+	switch {
+	case op == Add && i < 0:
+		doc.Directives = append(doc.Directives, value)
+	case op == Add && sameSDL(&ast.SchemaDocument{Directives: ast.DirectiveDefinitionList{doc.Directives[i]}}, src):
+	case i < 0:
+		return fmt.Errorf("directive does not exist")
+	case op == Add:
+		return fmt.Errorf("directive already exists")
+	case op == Replace:
+		doc.Directives[i] = value
+	case op == Remove:
+		doc.Directives = append(doc.Directives[:i:i], doc.Directives[i+1:]...)
+	}
+	return nil
+}
+
+func applyDefinition(doc *ast.SchemaDocument, op, name string, src *ast.SchemaDocument) error {
+	i := -1
+	for j, def := range doc.Definitions {
+		if def.Name == name {
+			i = j
+		}
+	}
+
+	var value *ast.Definition
+	if src != nil {
+		if len(src.Definitions) != 1 || src.Definitions[0].Name != name {
+			return fmt.Errorf("SDL does not define type '%s'", name)
+		}
+		value = src.Definitions[0]
+	}
+
+	switch {
+	case op == Add && i < 0:
+		doc.Definitions = append(doc.Definitions, value)
+	case op == Add:
+		header := *doc.Definitions[i]
+		header.Fields, header.EnumValues = nil, nil
+		if !sameSDL(&ast.SchemaDocument{Definitions: ast.DefinitionList{&header}}, src) {
+			return fmt.Errorf("type already exists")
+		}
+	case i < 0:
+		return fmt.Errorf("type does not exist")
+	case op == Replace:
+		def := doc.Definitions[i]
+		if def.Kind != value.Kind {
+			return fmt.Errorf("type is %s, not %s", def.Kind, value.Kind)
+		}
+		cp := *value
+		cp.Fields, cp.EnumValues = def.Fields, def.EnumValues
+		doc.Definitions[i] = &cp
+	case op == Remove:
+		doc.Definitions = append(doc.Definitions[:i:i], doc.Definitions[i+1:]...)
+	}
+	return nil
+}
+
+func applyMember(doc *ast.SchemaDocument, op, typeName, name string, src *ast.SchemaDocument) error {
+	var def *ast.Definition
+	for _, d := range doc.Definitions {
+		if d.Name == typeName {
+			def = d
+		}
+	}
+	if def == nil {
+		return fmt.Errorf("type '%s' does not exist", typeName)
+	}
+
+	if src != nil {
+		if len(src.Definitions) != 1 || src.Definitions[0].Name != typeName || src.Definitions[0].Kind != def.Kind {
+			return fmt.Errorf("SDL does not define %s '%s'", def.Kind, typeName)
+		}
+	}
+
+	if def.Kind == ast.Enum {
+		var value *ast.EnumValueDefinition
+		if src != nil {
+			if value = src.Definitions[0].EnumValues.ForName(name); value == nil {
+				return fmt.Errorf("SDL does not define enum value '%s'", name)
+			}
+		}
+		i := -1
+		for j, v := range def.EnumValues {
+			if v.Name == name {
+				i = j
+			}
+		}
+
+		switch {
+		case op == Add && i < 0:
+			def.EnumValues = append(def.EnumValues, value)
+		case op == Add && sameSDL(&ast.SchemaDocument{Definitions: ast.DefinitionList{{Kind: def.Kind, Name: def.Name, EnumValues: ast.EnumValueList{def.EnumValues[i]}}}}, src):
+		case i < 0:
+			return fmt.Errorf("enum value does not exist")
+		case op == Add:
+			return fmt.Errorf("enum value already exists")
+		case op == Replace:
+			def.EnumValues[i] = value
+		case op == Remove:
+			def.EnumValues = append(def.EnumValues[:i:i], def.EnumValues[i+1:]...)
+		}
+		return nil
+	}
+
+	var value *ast.FieldDefinition
+	if src != nil {
+		if value = src.Definitions[0].Fields.ForName(name); value == nil {
+			return fmt.Errorf("SDL does not define field '%s'", name)
+		}
+	}
+	i := -1
+	for j, f := range def.Fields {
+		if f.Name == name {
+			i = j
+		}
+	}
+
+	switch {
+	case op == Add && i < 0:
+		def.Fields = append(def.Fields, value)
+	case op == Add && sameSDL(&ast.SchemaDocument{Definitions: ast.DefinitionList{{Kind: def.Kind, Name: def.Name, Fields: ast.FieldList{def.Fields[i]}}}}, src):
+	case i < 0:
+		return fmt.Errorf("field does not exist")
+	case op == Add:
+		return fmt.Errorf("field already exists")
+	case op == Replace:
+		def.Fields[i] = value
+	case op == Remove:
+		def.Fields = append(def.Fields[:i:i], def.Fields[i+1:]...)
+	}
+	return nil
+}
+
+// sameSDL tells whether two documents print the same.
+func sameSDL(x, y *ast.SchemaDocument) bool {
+	var bx, by bytes.Buffer
+	if err := format.Document(&bx, x, format.Options{StripComments: true}); err != nil {
+		return false
+	}
+	if err := format.Document(&by, y, format.Options{StripComments: true}); err != nil {
+		return false
+	}
+	return bytes.Equal(bx.Bytes(), by.Bytes())
+}
@@ -0,0 +1,253 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestCreate(t *testing.T) {
+	x := `
+schema { query: Query }
+directive @key(fields: String!) on OBJECT
+type Query { hero(id: ID): Hero }
+type Hero @key(fields: "id") { id: ID! name: String }
+enum Episode { NEW_HOPE EMPIRE }
+union Result = Hero
+`
+	y := `
+schema { query: Query mutation: Mutation }
+type Query { hero(id: ID!): Hero }
+"Hero"
+type Hero @key(fields: "id") { id: ID! name: String friends: [Hero] }
+enum Episode { NEW_HOPE JEDI }
+interface Result { id: ID! }
+type Mutation { addHero(name: String!): Hero }
+`
+	want := []string{
+		"remove @key",
+		"remove Episode.EMPIRE",
+		"remove Result",
+		"add schema.mutation",
+		"replace Query.hero",
+		"replace Hero",
+		"add Hero.friends",
+		"add Episode.JEDI",
+		"add Result",
+		"add Result.id",
+		"add Mutation",
+		"add Mutation.addHero",
+	}
+
+	p, err := Create(parse(t, "x", x), parse(t, "y", y))
+	if err != nil {
+		t.Fatalf("unable to create patch: %v", err)
+	}
+
+	var have []string
+	for _, op := range p.Operations {
+		have = append(have, op.Op+" "+op.Coordinate)
+	}
+	if !reflect.DeepEqual(want, have) {
+		t.Fatalf("invalid operations:\nwant %q\nhave %q", want, have)
+	}
+	if op := p.Operations[4]; op.SDL != "type Query {\n  hero(id: ID!): Hero\n}\n" {
+		t.Errorf("invalid SDL of %s: %q", op.Coordinate, op.SDL)
+	}
+}
+
+func TestCreateCoversComparison(t *testing.T) {
+	x := `
+directive @auth(role: String) on FIELD_DEFINITION
+type Query { hero(id: ID, episode: Episode = NEW_HOPE): Hero search: [Result] }
+"A hero"
+interface Character { name: String }
+type Hero implements Character { id: ID! name: String friends(first: Int): [Hero] }
+type Droid { model: String }
+enum Episode { NEW_HOPE EMPIRE }
+union Result = Hero | Droid
+input Filter { name: String limit: Int = 10 }
+`
+	y := `
+directive @auth(role: String!, scope: String) on FIELD_DEFINITION | OBJECT
+type Query { hero(id: ID!, episode: Episode = JEDI): Hero search: [Result] filter(by: Filter): [Hero] }
+interface Character { name: String! }
+type Hero { id: ID name: String friends(first: Int, after: String): [Hero!] }
+scalar Droid
+enum Episode { NEW_HOPE JEDI }
+union Result = Hero
+input Filter { name: String! limit: Int = 20 episode: Episode }
+type Mutation { addHero(name: String!): Hero }
+`
+	sx, sy := parse(t, "x", x), parse(t, "y", y)
+	res, err := compare.Documents(sx, sy)
+	if err != nil {
+		t.Fatalf("unable to compare schemas: %v", err)
+	}
+	p, err := Create(sx, sy)
+	if err != nil {
+		t.Fatalf("unable to create patch: %v", err)
+	}
+
+	covered := func(path string) bool {
+		for _, op := range p.Operations {
+			for _, c := range []string{op.Coordinate, strings.TrimPrefix(op.Coordinate, "@")} {
+				if path == c || strings.HasPrefix(path, c+".") {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if len(res.Changes()) == 0 {
+		t.Fatal("no changes detected")
+	}
+	for _, c := range res.Changes() {
+		if !covered(c.Path) {
+			t.Errorf("change %s of '%s' not covered by the patch", c.Type, c.Path)
+		}
+	}
+}
+
+func TestCreateFollowsComparison(t *testing.T) {
+	x := `type Query { a: Int b: Int } enum Episode { NEW_HOPE }`
+	y := `type Query { a: Int @deprecated b: String } enum Episode { "A new hope" NEW_HOPE }`
+
+	p, err := Create(parse(t, "x", x), parse(t, "y", y))
+	if err != nil {
+		t.Fatalf("unable to create patch: %v", err)
+	}
+	var have []string
+	for _, op := range p.Operations {
+		have = append(have, op.Op+" "+op.Coordinate)
+	}
+	if want := []string{"replace Query.b"}; !reflect.DeepEqual(want, have) {
+		t.Errorf("invalid operations:\nwant %q\nhave %q", want, have)
+	}
+}
+
+func TestApply(t *testing.T) {
+	testData := []struct {
+		name  string
+		x, y  string
+		other string
+		want  string
+		err   string
+	}{
+		{
+			name: "Changes are ported to another schema",
+			x:    `type Query { hero: Hero } type Hero { name: String } enum Episode { NEW_HOPE }`,
+			y:    `type Query { hero(id: ID): Hero } type Hero { name: String! id: ID } enum Episode { NEW_HOPE EMPIRE }`,
+			other: `
+# customer fork
+type Query { hero: Hero droid: Droid }
+type Hero { name: String }
+type Droid { name: String }
+enum Episode { NEW_HOPE }
+`,
+			want: `# customer fork
+type Query {
+  hero(id: ID): Hero
+  droid: Droid
+}
+
+type Hero {
+  name: String!
+  id: ID
+}
+
+type Droid {
+  name: String
+}
+
+enum Episode {
+  NEW_HOPE
+  EMPIRE
+}
+`,
+		},
+		{
+			name:  "Changes already present are skipped",
+			x:     `type Query { a: Int }`,
+			y:     `type Query { a: Int b: String }`,
+			other: `type Query { b: String a: Int }`,
+			want: `type Query {
+  b: String
+  a: Int
+}
+`,
+		},
+		{
+			name:  "Conflicting changes fail",
+			x:     `type Query { a: Int }`,
+			y:     `type Query { a: Int b: String }`,
+			other: `type Query { a: Int b: Int }`,
+			err:   "unable to add 'Query.b': field already exists",
+		},
+		{
+			name:  "Changes of missing items fail",
+			x:     `type Query { a: Int } type Hero { name: String }`,
+			y:     `type Query { a: Int } type Hero { name: String! }`,
+			other: `type Query { a: Int }`,
+			err:   "unable to replace 'Hero.name': type 'Hero' does not exist",
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			p, err := Create(parse(t, "x", s.x), parse(t, "y", s.y))
+			if err != nil {
+				t.Fatalf("unable to create patch: %v", err)
+			}
+
+			// the patch must survive serialization
+			b, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("unable to encode patch: %v", err)
+			}
+			if p, err = Read(bytes.NewReader(b)); err != nil {
+				t.Fatalf("unable to decode patch: %v", err)
+			}
+
+			doc, err := Apply(parse(t, "other", s.other), p)
+			if s.err != "" {
+				if err == nil || err.Error() != s.err {
+					t.Fatalf("invalid error: want %q, have %v", s.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to apply patch: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				t.Fatalf("unable to print schema: %v", err)
+			}
+			if s.want != buf.String() {
+				t.Errorf("invalid schema:\nwant:\n%s\nhave:\n%s", s.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	if _, err := Read(strings.NewReader(`{"operations": [{"op": "move", "coordinate": "Query"}]}`)); err == nil {
+		t.Error("unknown operation should be rejected")
+	}
+}
+
+func parse(t *testing.T, name, sdl string) *ast.SchemaDocument {
+	doc, err := parser.ParseSchema(&ast.Source{Name: name, Input: sdl})
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+	return doc
+}
@@ -0,0 +1,644 @@
+package proto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Options configures the conversion.
+type Options struct {
+
+	// FieldNames is the naming of fields and arguments: camel turns them into lowerCamelCase as the JSON mapping
+	// of proto3 does, honoring json_name, proto keeps them as declared. Camel unless set
+	FieldNames string
+
+	// TypePrefix is prepended to the names of the generated object, input and enum types
+	TypePrefix string
+
+	// TrimEnumPrefix removes the enum name in upper snake case from its values, e.g. STATUS_ACTIVE of Status becomes ACTIVE
+	TrimEnumPrefix bool
+
+	// Wrappers keeps the well-known wrapper types, e.g. google.protobuf.StringValue, as object types
+	// instead of turning them into nullable scalars
+	Wrappers bool
+
+	// QueryPrefixes are the prefixes of the names of the methods turned into Query fields, the other methods become
+	// Mutation fields. Get, List, Search, Find, Lookup, Count and BatchGet unless set
+	QueryPrefixes []string
+}
+
+var defaultQueryPrefixes = []string{"Get", "List", "Search", "Find", "Lookup", "Count", "BatchGet"}
+
+// scalars maps the scalar value types to GraphQL scalars. 64-bit integers are strings as in the JSON mapping of proto3
+// and unsigned 32-bit integers exceed Int.
+var scalars = map[string]string{
+	"double":   "Float",
+	"float":    "Float",
+	"int32":    "Int",
+	"sint32":   "Int",
+	"sfixed32": "Int",
+	"uint32":   "Float",
+	"fixed32":  "Float",
+	"int64":    "String",
+	"sint64":   "String",
+	"sfixed64": "String",
+	"uint64":   "String",
+	"fixed64":  "String",
+	"bool":     "Boolean",
+	"string":   "String",
+	"bytes":    "String",
+}
+
+// wrappers maps the well-known wrapper types to the scalars of their values.
+var wrappers = map[string]string{
+	"google.protobuf.DoubleValue": "Float",
+	"google.protobuf.FloatValue":  "Float",
+	"google.protobuf.Int64Value":  "String",
+	"google.protobuf.UInt64Value": "String",
+	"google.protobuf.Int32Value":  "Int",
+	"google.protobuf.UInt32Value": "Float",
+	"google.protobuf.BoolValue":   "Boolean",
+	"google.protobuf.StringValue": "String",
+	"google.protobuf.BytesValue":  "String",
+}
+
+// wellKnown maps the other well-known types to scalars, the custom ones are declared with the description given.
+var wellKnown = map[string]struct{ scalar, description string }{
+	"google.protobuf.Timestamp": {"Timestamp", "Point in time encoded as an RFC 3339 string, google.protobuf.Timestamp."},
+	"google.protobuf.Duration":  {"Duration", "Span of time encoded as seconds with the suffix s, e.g. 1.5s, google.protobuf.Duration."},
+	"google.protobuf.Struct":    {"JSON", "Any JSON value."},
+	"google.protobuf.Value":     {"JSON", "Any JSON value."},
+	"google.protobuf.ListValue": {"JSON", "Any JSON value."},
+	"google.protobuf.Any":       {"JSON", "Any JSON value."},
+	"google.protobuf.FieldMask": {"String", ""},
+}
+
+const empty = "google.protobuf.Empty"
+
+// Convert converts the proto files into a GraphQL schema. The services become the fields of Query, Mutation and,
+// for server streaming methods, Subscription: the fields of the request message become the arguments and the
+// response message the type of the field. Client streaming methods are left out. The messages the methods refer to
+// become object types, or input types suffixed by Input when used as arguments, and the enums become enums.
+// Files without services have all their messages and enums converted into object types and enums. Without any query
+// methods Query gets a placeholder field, a schema requires it. The types are resolved in the files and in deps,
+// the files they import.
+func Convert(files, deps []*File, opts Options) (*ast.SchemaDocument, error) {
+	if opts.FieldNames == "" {
+		opts.FieldNames = "camel"
+	}
+	if opts.FieldNames != "camel" && opts.FieldNames != "proto" {
+		return nil, fmt.Errorf("unsupported field names '%s'", opts.FieldNames)
+	}
+	if opts.QueryPrefixes == nil {
+		opts.QueryPrefixes = defaultQueryPrefixes
+	}
+
+	c := &converter{
+		opts:  opts,
+		types: make(map[string]*declaration),
+		names: make(map[string]string),
+		done:  make(map[string]*ast.Definition),
+	}
+	for _, f := range append(append([]*File{}, files...), deps...) {
+		c.declare(f)
+	}
+	if err := c.reserve("Query", rootOwner); err != nil {
+		return nil, err
+	}
+
+	services := false
+	roots := make(map[ast.Operation]*ast.Definition)
+	var rootNames []ast.Operation
+	for _, f := range files {
+		for _, s := range f.Services {
+			services = true
+			for _, m := range s.Methods {
+				if m.ClientStreaming {
+					continue
+				}
+				op := c.operation(m)
+				if roots[op] == nil {
+					if err := c.reserve(rootName(op), rootOwner); err != nil {
+						return nil, err
+					}
+					roots[op] = &ast.Definition{Kind: ast.Object, Name: rootName(op)}
+					rootNames = append(rootNames, op)
+				}
+				if err := c.method(roots[op], f, s, m); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if !services {
+		for _, f := range files {
+			for _, t := range c.declared(f) {
+				var err error
+				if t.message != nil {
+					_, err = c.object(t, false)
+				} else {
+					_, err = c.enum(t)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if roots[ast.Query] == nil {
+		roots[ast.Query] = &ast.Definition{
+			Kind: ast.Object,
+			Name: rootName(ast.Query),
+			Fields: ast.FieldList{{
+				Name:        "_",
+				Description: "Placeholder, there are no query methods.",
+				Type:        ast.NamedType("Boolean", nil),
+			}},
+		}
+		rootNames = append(rootNames, ast.Query)
+	}
+
+	doc := &ast.SchemaDocument{}
+	sort.Slice(rootNames, func(i, j int) bool {
+		return operationOrder[rootNames[i]] < operationOrder[rootNames[j]]
+	})
+	for _, op := range rootNames {
+		doc.Definitions = append(doc.Definitions, roots[op])
+	}
+	doc.Definitions = append(doc.Definitions, c.defs...)
+	return doc, nil
+}
+
+// rootOwner and wellKnownOwner own the names of the root operation types and of the scalars of well-known types.
+const (
+	rootOwner      = "the root operation type"
+	wellKnownOwner = "the scalar of well-known types"
+)
+
+var operationOrder = map[ast.Operation]int{ast.Query: 0, ast.Mutation: 1, ast.Subscription: 2}
+
+func rootName(op ast.Operation) string {
+	return strings.ToUpper(string(op[:1])) + string(op[1:])
+}
+
+// declaration is a message or an enum along with its fully qualified name.
+type declaration struct {
+	full    string
+	name    string
+	message *Message
+	enum    *Enum
+}
+
+type converter struct {
+	opts Options
+
+	// types holds the declarations by fully qualified name
+	types map[string]*declaration
+
+	// names maps the GraphQL names of the generated types to what they were generated for, see reserve
+	names map[string]string
+
+	// defs are the generated definitions in order of generation, done holds them by name
+	defs []*ast.Definition
+	done map[string]*ast.Definition
+}
+
+// declare registers the messages and enums of the file, nested ones are named after their parents, e.g. User.Address
+// becomes UserAddress.
+func (c *converter) declare(f *File) {
+	for _, t := range c.walk(f.Package, "", f.Messages, f.Enums) {
+		if _, ok := c.types[t.full]; !ok {
+			c.types[t.full] = t
+		}
+	}
+}
+
+func (c *converter) walk(scope, parent string, messages []*Message, enums []*Enum) []*declaration {
+	var res []*declaration
+	qualify := func(name string) string {
+		if scope == "" {
+			return name
+		}
+		return scope + "." + name
+	}
+	for _, m := range messages {
+		t := &declaration{full: qualify(m.Name), name: parent + m.Name, message: m}
+		res = append(res, t)
+		res = append(res, c.walk(t.full, t.name, m.Messages, m.Enums)...)
+	}
+	for _, e := range enums {
+		res = append(res, &declaration{full: qualify(e.Name), name: parent + e.Name, enum: e})
+	}
+	return res
+}
+
+// declared returns the declarations of the file in order of declaration.
+func (c *converter) declared(f *File) []*declaration {
+	var res []*declaration
+	for _, t := range c.walk(f.Package, "", f.Messages, f.Enums) {
+		res = append(res, c.types[t.full])
+	}
+	return res
+}
+
+// resolve looks up a type referred to in the scope, innermost scopes first, as protoc does.
+func (c *converter) resolve(scope, name string) *declaration {
+	if strings.HasPrefix(name, ".") {
+		return c.types[name[1:]]
+	}
+	for {
+		full := name
+		if scope != "" {
+			full = scope + "." + name
+		}
+		if t, ok := c.types[full]; ok {
+			return t
+		}
+		if scope == "" {
+			return nil
+		}
+		if i := strings.LastIndex(scope, "."); i >= 0 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+func (c *converter) operation(m *Method) ast.Operation {
+	if m.ServerStreaming {
+		return ast.Subscription
+	}
+	for _, prefix := range c.opts.QueryPrefixes {
+		if hasWordPrefix(m.Name, prefix) {
+			return ast.Query
+		}
+	}
+	return ast.Mutation
+}
+
+// hasWordPrefix tells whether the name starts with the prefix followed by another word, e.g. GetUser but not Getaway.
+func hasWordPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	rest := []rune(name[len(prefix):])
+	return len(rest) == 0 || !unicode.IsLower(rest[0])
+}
+
+func (c *converter) method(root *ast.Definition, f *File, s *Service, m *Method) error {
+	name := lowerFirst(m.Name)
+	if prev := root.Fields.ForName(name); prev != nil {
+		return fmt.Errorf("method '%s.%s': field '%s' of %s defined twice", s.Name, m.Name, name, root.Name)
+	}
+	field := &ast.FieldDefinition{
+		Name:        name,
+		Description: m.Comment,
+		Directives:  deprecated(m.Deprecated),
+	}
+
+	scope := f.Package
+	if !isEmpty(m.Input) {
+		in := c.resolve(scope, m.Input)
+		if in == nil || in.message == nil {
+			return fmt.Errorf("method '%s.%s': message '%s' not found", s.Name, m.Name, m.Input)
+		}
+		for _, inf := range in.message.Fields {
+			t, err := c.fieldType(in.full, inf, true)
+			if err != nil {
+				return fmt.Errorf("method '%s.%s': field '%s': %v", s.Name, m.Name, inf.Name, err)
+			}
+			field.Arguments = append(field.Arguments, &ast.ArgumentDefinition{
+				Name:        c.fieldName(inf),
+				Description: inf.Comment,
+				Type:        t,
+			})
+		}
+	}
+
+	if isEmpty(m.Output) {
+		field.Type = ast.NamedType("Boolean", nil)
+	} else {
+		t, err := c.typeRef(scope, m.Output, false)
+		if err != nil {
+			return fmt.Errorf("method '%s.%s': %v", s.Name, m.Name, err)
+		}
+		field.Type = t
+	}
+	root.Fields = append(root.Fields, field)
+	return nil
+}
+
+func isEmpty(name string) bool {
+	return strings.TrimPrefix(name, ".") == empty
+}
+
+// fieldType returns the GraphQL type of the field of the message with the fully qualified name. Output fields of scalars
+// and enums are non-null unless they are optional or belong to a oneof, messages are nullable. Input fields are nullable
+// as proto3 has default values for all of them. Repeated and map fields are lists of non-null items, the entries
+// of maps are objects with a key and a value.
+func (c *converter) fieldType(scope string, f *Field, input bool) (*ast.Type, error) {
+	var t *ast.Type
+	var err error
+	if f.KeyType != "" {
+		t, err = c.entry(scope, f, input)
+	} else {
+		t, err = c.typeRef(scope, f.Type, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Repeated || f.KeyType != "" {
+		t.NonNull = true
+		return &ast.Type{Elem: t, NonNull: !input}, nil
+	}
+	if input || f.Optional || f.Oneof != "" {
+		t.NonNull = false
+	}
+	return t, nil
+}
+
+// typeRef returns the type referred to in the scope: scalars and enums are non-null, messages nullable.
+func (c *converter) typeRef(scope, name string, input bool) (*ast.Type, error) {
+	if s, ok := scalars[name]; ok {
+		return ast.NonNullNamedType(s, nil), nil
+	}
+
+	t := c.resolve(scope, name)
+	if t == nil {
+		full := strings.TrimPrefix(name, ".")
+		if s, ok := wrappers[full]; ok {
+			if !c.opts.Wrappers {
+				return ast.NamedType(s, nil), nil
+			}
+			n, err := c.wrapper(full, s, input)
+			if err != nil {
+				return nil, err
+			}
+			return ast.NamedType(n, nil), nil
+		}
+		if wk, ok := wellKnown[full]; ok {
+			if wk.description != "" {
+				if err := c.reserve(wk.scalar, wellKnownOwner); err != nil {
+					return nil, err
+				}
+			}
+			if wk.description != "" && c.done[wk.scalar] == nil {
+				c.add(&ast.Definition{Kind: ast.Scalar, Name: wk.scalar, Description: wk.description})
+			}
+			return ast.NamedType(wk.scalar, nil), nil
+		}
+		if full == empty {
+			return nil, fmt.Errorf("type '%s' is only supported as the request or response of methods", name)
+		}
+		return nil, fmt.Errorf("type '%s' not found", name)
+	}
+
+	if t.enum != nil {
+		n, err := c.enum(t)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NonNullNamedType(n, nil), nil
+	}
+	n, err := c.object(t, input)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NamedType(n, nil), nil
+}
+
+// add appends the generated definition, it is added before its fields are so that the types refer to each other.
+func (c *converter) add(def *ast.Definition) {
+	c.defs = append(c.defs, def)
+	c.done[def.Name] = def
+}
+
+// reserve claims the GraphQL name for what a type is generated for, the names of distinct types must not clash.
+func (c *converter) reserve(name, owner string) error {
+	if prev, ok := c.names[name]; ok && prev != owner {
+		return fmt.Errorf("%s and %s are both named '%s'", prev, owner, name)
+	}
+	c.names[name] = owner
+	return nil
+}
+
+// typeName returns the GraphQL name of the declaration.
+func (c *converter) typeName(t *declaration, suffix string) (string, error) {
+	name := c.opts.TypePrefix + t.name + suffix
+	owner := fmt.Sprintf("message '%s'", t.full)
+	if t.enum != nil {
+		owner = fmt.Sprintf("enum '%s'", t.full)
+	}
+	return name, c.reserve(name, owner)
+}
+
+func (c *converter) enum(t *declaration) (string, error) {
+	name, err := c.typeName(t, "")
+	if err != nil {
+		return "", err
+	}
+	if c.done[name] != nil {
+		return name, nil
+	}
+
+	prefix := ""
+	if c.opts.TrimEnumPrefix {
+		prefix = upperSnake(t.enum.Name) + "_"
+		for _, v := range t.enum.Values {
+			rest := strings.TrimPrefix(v.Name, prefix)
+			if rest == v.Name || rest == "" || !isNameStart(rest[0]) {
+				prefix = ""
+				break
+			}
+		}
+	}
+
+	def := &ast.Definition{Kind: ast.Enum, Name: name, Description: t.enum.Comment}
+	for _, v := range t.enum.Values {
+		def.EnumValues = append(def.EnumValues, &ast.EnumValueDefinition{
+			Name:        strings.TrimPrefix(v.Name, prefix),
+			Description: v.Comment,
+			Directives:  deprecated(v.Deprecated),
+		})
+	}
+	c.add(def)
+	return name, nil
+}
+
+func (c *converter) object(t *declaration, input bool) (string, error) {
+	kind, suffix := ast.Object, ""
+	if input {
+		kind, suffix = ast.InputObject, "Input"
+	}
+	name, err := c.typeName(t, suffix)
+	if err != nil {
+		return "", err
+	}
+	if c.done[name] != nil {
+		return name, nil
+	}
+
+	def := &ast.Definition{Kind: kind, Name: name, Description: t.message.Comment}
+	c.add(def)
+	for _, f := range t.message.Fields {
+		typ, err := c.fieldType(t.full, f, input)
+		if err != nil {
+			return "", fmt.Errorf("message '%s': field '%s': %v", t.full, f.Name, err)
+		}
+		fd := &ast.FieldDefinition{
+			Name:        c.fieldName(f),
+			Description: f.Comment,
+			Type:        typ,
+		}
+		if !input {
+			// deprecation of input fields is not part of the specification
+			fd.Directives = deprecated(f.Deprecated)
+		}
+		def.Fields = append(def.Fields, fd)
+	}
+	placeholder(def)
+	return name, nil
+}
+
+// entry returns the type of the entries of the map field, an object with the key and the value.
+func (c *converter) entry(scope string, f *Field, input bool) (*ast.Type, error) {
+	parent := c.types[scope]
+	name := c.opts.TypePrefix + parent.name + pascal(f.Name) + "Entry"
+	kind := ast.Object
+	if input {
+		name += "Input"
+		kind = ast.InputObject
+	}
+	if err := c.reserve(name, fmt.Sprintf("the entries of '%s.%s'", scope, f.Name)); err != nil {
+		return nil, err
+	}
+	if c.done[name] == nil {
+		key, err := c.typeRef(scope, f.KeyType, input)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.typeRef(scope, f.Type, input)
+		if err != nil {
+			return nil, err
+		}
+		if input {
+			value.NonNull = false
+		}
+		c.add(&ast.Definition{
+			Kind: kind,
+			Name: name,
+			Fields: ast.FieldList{
+				{Name: "key", Type: key},
+				{Name: "value", Type: value},
+			},
+		})
+	}
+	return ast.NamedType(name, nil), nil
+}
+
+// wrapper returns the object type of the wrapper type, named as the message unless prefixed.
+func (c *converter) wrapper(full, scalar string, input bool) (string, error) {
+	name := c.opts.TypePrefix + full[strings.LastIndex(full, ".")+1:]
+	kind := ast.Object
+	if input {
+		name += "Input"
+		kind = ast.InputObject
+	}
+	if err := c.reserve(name, fmt.Sprintf("message '%s'", full)); err != nil {
+		return "", err
+	}
+	if c.done[name] == nil {
+		c.add(&ast.Definition{
+			Kind:   kind,
+			Name:   name,
+			Fields: ast.FieldList{{Name: "value", Type: ast.NonNullNamedType(scalar, nil)}},
+		})
+	}
+	return name, nil
+}
+
+// placeholder adds a field to types of messages without fields, GraphQL requires at least one.
+func placeholder(def *ast.Definition) {
+	if len(def.Fields) == 0 {
+		def.Fields = ast.FieldList{{
+			Name:        "_",
+			Description: "Placeholder, the message has no fields.",
+			Type:        ast.NamedType("Boolean", nil),
+		}}
+	}
+}
+
+func deprecated(ok bool) ast.DirectiveList {
+	if !ok {
+		return nil
+	}
+	return ast.DirectiveList{{Name: "deprecated"}}
+}
+
+func (c *converter) fieldName(f *Field) string {
+	if c.opts.FieldNames == "proto" {
+		return f.Name
+	}
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+	return lowerCamel(f.Name)
+}
+
+// lowerCamel converts the name as the JSON mapping of proto3 does, e.g. user_id becomes userId.
+func lowerCamel(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func pascal(name string) string {
+	s := lowerCamel(name)
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// upperSnake converts the name into upper snake case, e.g. PhoneType becomes PHONE_TYPE.
+func upperSnake(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+			i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
@@ -0,0 +1,69 @@
+package proto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Load parses the proto files and, as deps, the files they import directly or indirectly. Imports are looked up
+// in the import paths and then in the directory of the importing file. The well-known types of google/protobuf
+// are built in and not read.
+func Load(names []string, paths []string) ([]*File, []*File, error) {
+	var files, deps []*File
+	seen := make(map[string]bool)
+	for _, name := range names {
+		f, err := parseFile(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		seen[filepath.Clean(name)] = true
+		files = append(files, f)
+	}
+
+	queue := append([]*File{}, files...)
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		for _, imp := range f.Imports {
+			if strings.HasPrefix(imp, "google/protobuf/") {
+				continue
+			}
+			name, err := lookup(imp, append(append([]string{}, paths...), filepath.Dir(f.Name)))
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			dep, err := parseFile(name)
+			if err != nil {
+				return nil, nil, err
+			}
+			deps = append(deps, dep)
+			queue = append(queue, dep)
+		}
+	}
+	return files, deps, nil
+}
+
+func lookup(imp string, paths []string) (string, error) {
+	for _, dir := range paths {
+		name := filepath.Clean(filepath.Join(dir, imp))
+		if _, err := os.Stat(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("import '%s' not found", imp)
+}
+
+func parseFile(name string) (*File, error) {
+	r, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return Parse(name, r)
+}
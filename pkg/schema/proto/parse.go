@@ -0,0 +1,807 @@
+package proto
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// File is a parsed proto3 file, only the declarations needed to describe the API are kept.
+type File struct {
+
+	// Name is the name the file was parsed under
+	Name string
+
+	// Package is the package declared, empty unless declared
+	Package string
+
+	// Imports are the paths of the imported files
+	Imports []string
+
+	Messages []*Message
+	Enums    []*Enum
+	Services []*Service
+}
+
+// Message is a message declaration along with its nested declarations.
+type Message struct {
+	Name    string
+	Comment string
+
+	Fields   []*Field
+	Messages []*Message
+	Enums    []*Enum
+}
+
+// Field is a field of a message, the fields of oneofs included.
+type Field struct {
+	Name    string
+	Comment string
+	Number  int
+
+	// Type is the type as referred to in the file, e.g. string, Address or google.protobuf.Timestamp
+	Type string
+
+	// KeyType is the key type of map fields, empty for other fields
+	KeyType string
+
+	// Oneof is the name of the oneof the field belongs to, empty unless it belongs to one
+	Oneof string
+
+	// JSONName is the value of the json_name option, empty unless set
+	JSONName string
+
+	Repeated   bool
+	Optional   bool
+	Deprecated bool
+}
+
+// Enum is an enum declaration.
+type Enum struct {
+	Name    string
+	Comment string
+	Values  []*EnumValue
+}
+
+// EnumValue is a value of an enum.
+type EnumValue struct {
+	Name       string
+	Comment    string
+	Number     int
+	Deprecated bool
+}
+
+// Service is a service declaration.
+type Service struct {
+	Name    string
+	Comment string
+	Methods []*Method
+}
+
+// Method is an rpc of a service.
+type Method struct {
+	Name    string
+	Comment string
+
+	// Input and Output are the message types as referred to in the file
+	Input  string
+	Output string
+
+	ClientStreaming bool
+	ServerStreaming bool
+	Deprecated      bool
+}
+
+// Parse parses a proto3 file. Comments directly preceding a declaration are kept as its comment.
+func Parse(name string, r io.Reader) (*File, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read proto file '%s': %v", name, err)
+	}
+	toks, err := tokenize(name, string(b))
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{name: name, toks: toks}
+	f, err := p.file()
+	if err != nil {
+		return nil, err
+	}
+	f.Name = name
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind      tokenKind
+	text      string
+	line, col int
+
+	// comment holds the comments on the lines directly preceding the token
+	comment string
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of file"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return "'" + t.text + "'"
+}
+
+// tokenize splits the source into tokens. Identifiers include their dots, e.g. google.protobuf.Timestamp or .pkg.Message.
+func tokenize(name, src string) ([]token, error) {
+	var (
+		toks    []token
+		input   = []rune(src)
+		line    = 1
+		col     = 1
+		comment []string
+
+		// commentEnd is the line the pending comments end on, lastLine the line of the last token
+		commentEnd = 0
+		lastLine   = 0
+	)
+	advance := func(n int) {
+		for ; n > 0 && len(input) > 0; n-- {
+			if input[0] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			input = input[1:]
+		}
+	}
+	addComment := func(startLine int, text []string) {
+		if startLine == lastLine {
+			// trails the previous token
+			return
+		}
+		if commentEnd > 0 && startLine > commentEnd+1 {
+			comment = nil
+		}
+		comment = append(comment, text...)
+		commentEnd = line
+	}
+
+	for len(input) > 0 {
+		c := input[0]
+		switch {
+		case unicode.IsSpace(c):
+			advance(1)
+		case c == '/' && len(input) > 1 && input[1] == '/':
+			start := line
+			end := 0
+			for end < len(input) && input[end] != '\n' {
+				end++
+			}
+			text := strings.TrimPrefix(string(input[2:end]), " ")
+			advance(end)
+			addComment(start, []string{strings.TrimRight(text, " \t\r")})
+		case c == '/' && len(input) > 1 && input[1] == '*':
+			start, startCol := line, col
+			end := strings.Index(string(input[2:]), "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%s:%d:%d: unterminated comment", name, start, startCol)
+			}
+			body := []rune(string(input[2:])[:end])
+			var text []string
+			for _, l := range strings.Split(string(body), "\n") {
+				l = strings.TrimSpace(l)
+				l = strings.TrimPrefix(strings.TrimPrefix(l, "*"), " ")
+				text = append(text, strings.TrimRight(l, " \t\r"))
+			}
+			for len(text) > 0 && text[0] == "" {
+				text = text[1:]
+			}
+			for len(text) > 0 && text[len(text)-1] == "" {
+				text = text[:len(text)-1]
+			}
+			advance(len(body) + 4)
+			addComment(start, text)
+		default:
+			t := token{line: line, col: col}
+			if commentEnd > 0 && commentEnd >= line-1 {
+				t.comment = strings.Join(comment, "\n")
+			}
+			comment, commentEnd = nil, 0
+
+			n := 1
+			switch {
+			case isIdentStart(c) || c == '.' && len(input) > 1 && isIdentStart(input[1]):
+				t.kind = tokenIdent
+				for n < len(input) && (isIdentStart(input[n]) || unicode.IsDigit(input[n]) ||
+					input[n] == '.' && n+1 < len(input) && isIdentStart(input[n+1])) {
+					n++
+				}
+				t.text = string(input[:n])
+			case unicode.IsDigit(c) || c == '.' && len(input) > 1 && unicode.IsDigit(input[1]):
+				t.kind = tokenNumber
+				for n < len(input) && (unicode.IsLetter(input[n]) || unicode.IsDigit(input[n]) || input[n] == '.' ||
+					(input[n] == '-' || input[n] == '+') && (input[n-1] == 'e' || input[n-1] == 'E')) {
+					n++
+				}
+				t.text = string(input[:n])
+			case c == '"' || c == '\'':
+				t.kind = tokenString
+				for n < len(input) && input[n] != c && input[n] != '\n' {
+					if input[n] == '\\' {
+						n++
+					}
+					n++
+				}
+				if n >= len(input) || input[n] != c {
+					return nil, fmt.Errorf("%s:%d:%d: unterminated string", name, t.line, t.col)
+				}
+				t.text = unquote(string(input[1:n]))
+				n++
+			default:
+				t.kind = tokenSymbol
+				t.text = string(c)
+			}
+			toks = append(toks, t)
+			lastLine = line
+			advance(n)
+		}
+	}
+	return append(toks, token{kind: tokenEOF, line: line, col: col}), nil
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || c < unicode.MaxASCII && unicode.IsLetter(c)
+}
+
+// unquote resolves the escape sequences of a string literal, invalid ones are kept as written.
+func unquote(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	if u, err := strconv.Unquote(`"` + strings.Replace(s, `"`, `\"`, -1) + `"`); err == nil {
+		return u
+	}
+	return s
+}
+
+type parser struct {
+	name string
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("%s:%d:%d: %s", p.name, t.line, t.col, fmt.Sprintf(format, args...))
+}
+
+// expect consumes the symbol or keyword.
+func (p *parser) expect(text string) error {
+	t := p.next()
+	if t.kind == tokenString || t.text != text {
+		return p.errorf(t, "expected '%s', found %s", text, t)
+	}
+	return nil
+}
+
+func (p *parser) ident() (token, error) {
+	t := p.next()
+	if t.kind != tokenIdent {
+		return t, p.errorf(t, "expected identifier, found %s", t)
+	}
+	return t, nil
+}
+
+func (p *parser) number() (int, error) {
+	t := p.next()
+	sign := 1
+	if t.kind == tokenSymbol && (t.text == "-" || t.text == "+") {
+		if t.text == "-" {
+			sign = -1
+		}
+		t = p.next()
+	}
+	if t.kind != tokenNumber {
+		return 0, p.errorf(t, "expected number, found %s", t)
+	}
+	n, err := strconv.ParseInt(t.text, 0, 64)
+	if err != nil {
+		return 0, p.errorf(t, "invalid number '%s'", t.text)
+	}
+	return sign * int(n), nil
+}
+
+func (p *parser) file() (*File, error) {
+	f := &File{}
+	if t := p.peek(); t.text != "syntax" || t.kind != tokenIdent {
+		return nil, p.errorf(t, "missing syntax declaration, only proto3 is supported")
+	}
+	for {
+		t := p.next()
+		if t.kind == tokenEOF {
+			return f, nil
+		}
+		if t.kind == tokenSymbol && t.text == ";" {
+			continue
+		}
+		if t.kind != tokenIdent {
+			return nil, p.errorf(t, "unexpected %s", t)
+		}
+
+		var err error
+		switch t.text {
+		case "syntax":
+			err = p.syntax()
+		case "package":
+			var name token
+			if name, err = p.ident(); err == nil {
+				f.Package = strings.TrimPrefix(name.text, ".")
+				err = p.expect(";")
+			}
+		case "import":
+			if next := p.peek(); next.text == "public" || next.text == "weak" {
+				p.next()
+			}
+			path := p.next()
+			if path.kind != tokenString {
+				return nil, p.errorf(path, "expected import path, found %s", path)
+			}
+			f.Imports = append(f.Imports, path.text)
+			err = p.expect(";")
+		case "option":
+			_, _, err = p.option()
+			if err == nil {
+				err = p.expect(";")
+			}
+		case "message":
+			var m *Message
+			if m, err = p.message(t); err == nil {
+				f.Messages = append(f.Messages, m)
+			}
+		case "enum":
+			var e *Enum
+			if e, err = p.enum(t); err == nil {
+				f.Enums = append(f.Enums, e)
+			}
+		case "service":
+			var s *Service
+			if s, err = p.service(t); err == nil {
+				f.Services = append(f.Services, s)
+			}
+		case "extend":
+			// custom options, they do not affect the API
+			if _, err = p.ident(); err == nil {
+				err = p.skipBlock()
+			}
+		default:
+			return nil, p.errorf(t, "unexpected %s", t)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) syntax() error {
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	t := p.next()
+	if t.kind != tokenString {
+		return p.errorf(t, "expected syntax, found %s", t)
+	}
+	if t.text != "proto3" {
+		return p.errorf(t, "syntax '%s' is not supported, only proto3 is", t.text)
+	}
+	return p.expect(";")
+}
+
+// option parses the name and the value of an option up to, but not including, the terminating ';', ',' or ']'.
+// Aggregate values are skipped.
+func (p *parser) option() (string, string, error) {
+	var name strings.Builder
+	for {
+		t := p.next()
+		if t.kind == tokenEOF {
+			return "", "", p.errorf(t, "expected '=', found %s", t)
+		}
+		if t.kind == tokenSymbol && t.text == "=" {
+			break
+		}
+		name.WriteString(t.text)
+	}
+
+	t := p.peek()
+	if t.kind == tokenSymbol && t.text == "{" {
+		return name.String(), "", p.skipBlock()
+	}
+	var value strings.Builder
+	for {
+		t := p.peek()
+		if t.kind == tokenEOF || t.kind == tokenSymbol && (t.text == ";" || t.text == "," || t.text == "]") {
+			break
+		}
+		value.WriteString(p.next().text)
+	}
+	if value.Len() == 0 {
+		return "", "", p.errorf(p.peek(), "expected option value, found %s", p.peek())
+	}
+	return name.String(), value.String(), nil
+}
+
+// options parses the options of a field or an enum value enclosed in brackets, if any.
+func (p *parser) options() (map[string]string, error) {
+	opts := make(map[string]string)
+	if t := p.peek(); t.kind != tokenSymbol || t.text != "[" {
+		return opts, nil
+	}
+	p.next()
+	for {
+		name, value, err := p.option()
+		if err != nil {
+			return nil, err
+		}
+		opts[name] = value
+		t := p.next()
+		if t.kind == tokenSymbol && t.text == "]" {
+			return opts, nil
+		}
+		if t.kind != tokenSymbol || t.text != "," {
+			return nil, p.errorf(t, "expected ',' or ']', found %s", t)
+		}
+	}
+}
+
+// skipBlock skips a block enclosed in braces, including nested ones.
+func (p *parser) skipBlock() error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return p.errorf(t, "expected '}', found %s", t)
+		case t.kind == tokenSymbol && t.text == "{":
+			depth++
+		case t.kind == tokenSymbol && t.text == "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+// skipStatement skips up to and including the terminating ';'.
+func (p *parser) skipStatement() error {
+	for {
+		t := p.next()
+		if t.kind == tokenEOF {
+			return p.errorf(t, "expected ';', found %s", t)
+		}
+		if t.kind == tokenSymbol && t.text == ";" {
+			return nil
+		}
+	}
+}
+
+func (p *parser) message(keyword token) (*Message, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	m := &Message{Name: name.text, Comment: keyword.comment}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind == tokenSymbol && t.text == "}" {
+			p.next()
+			return m, nil
+		}
+		if t.kind == tokenSymbol && t.text == ";" {
+			p.next()
+			continue
+		}
+
+		switch t.text {
+		case "message":
+			p.next()
+			nested, err := p.message(t)
+			if err != nil {
+				return nil, err
+			}
+			m.Messages = append(m.Messages, nested)
+		case "enum":
+			p.next()
+			e, err := p.enum(t)
+			if err != nil {
+				return nil, err
+			}
+			m.Enums = append(m.Enums, e)
+		case "oneof":
+			p.next()
+			fields, err := p.oneof()
+			if err != nil {
+				return nil, err
+			}
+			m.Fields = append(m.Fields, fields...)
+		case "option", "reserved", "extensions":
+			p.next()
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		case "extend":
+			p.next()
+			if _, err := p.ident(); err != nil {
+				return nil, err
+			}
+			if err := p.skipBlock(); err != nil {
+				return nil, err
+			}
+		case "required", "group":
+			return nil, p.errorf(t, "'%s' is not supported, only proto3 is", t.text)
+		default:
+			f, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			m.Fields = append(m.Fields, f)
+		}
+	}
+}
+
+func (p *parser) field() (*Field, error) {
+	first := p.peek()
+	f := &Field{Comment: first.comment}
+	switch first.text {
+	case "repeated":
+		f.Repeated = true
+		p.next()
+	case "optional":
+		f.Optional = true
+		p.next()
+	}
+
+	if t := p.peek(); t.text == "map" && p.toks[p.pos+1].text == "<" {
+		p.next()
+		p.next()
+		key, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		value, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+		f.KeyType, f.Type = key.text, value.text
+	} else {
+		typ, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		f.Type = typ.text
+	}
+
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	f.Name = name.text
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	if f.Number, err = p.number(); err != nil {
+		return nil, err
+	}
+	opts, err := p.options()
+	if err != nil {
+		return nil, err
+	}
+	f.Deprecated = opts["deprecated"] == "true"
+	f.JSONName = opts["json_name"]
+	return f, p.expect(";")
+}
+
+func (p *parser) oneof() ([]*Field, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var fields []*Field
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenSymbol && t.text == "}":
+			p.next()
+			return fields, nil
+		case t.kind == tokenSymbol && t.text == ";":
+			p.next()
+		case t.text == "option":
+			p.next()
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		default:
+			f, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			f.Oneof = name.text
+			fields = append(fields, f)
+		}
+	}
+}
+
+func (p *parser) enum(keyword token) (*Enum, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	e := &Enum{Name: name.text, Comment: keyword.comment}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenSymbol && t.text == "}":
+			p.next()
+			return e, nil
+		case t.kind == tokenSymbol && t.text == ";":
+			p.next()
+		case t.text == "option" || t.text == "reserved":
+			p.next()
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		default:
+			name, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			v := &EnumValue{Name: name.text, Comment: name.comment}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			if v.Number, err = p.number(); err != nil {
+				return nil, err
+			}
+			opts, err := p.options()
+			if err != nil {
+				return nil, err
+			}
+			v.Deprecated = opts["deprecated"] == "true"
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+			e.Values = append(e.Values, v)
+		}
+	}
+}
+
+func (p *parser) service(keyword token) (*Service, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{Name: name.text, Comment: keyword.comment}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokenSymbol && t.text == "}":
+			return s, nil
+		case t.kind == tokenSymbol && t.text == ";":
+		case t.text == "option":
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		case t.text == "rpc":
+			m, err := p.method(t)
+			if err != nil {
+				return nil, err
+			}
+			s.Methods = append(s.Methods, m)
+		default:
+			return nil, p.errorf(t, "expected 'rpc', found %s", t)
+		}
+	}
+}
+
+func (p *parser) method(keyword token) (*Method, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	m := &Method{Name: name.text, Comment: keyword.comment}
+
+	// the message type in parentheses, optionally streamed
+	messageType := func() (string, bool, error) {
+		if err := p.expect("("); err != nil {
+			return "", false, err
+		}
+		stream := false
+		if t := p.peek(); t.text == "stream" && p.toks[p.pos+1].kind == tokenIdent {
+			stream = true
+			p.next()
+		}
+		typ, err := p.ident()
+		if err != nil {
+			return "", false, err
+		}
+		return typ.text, stream, p.expect(")")
+	}
+
+	if m.Input, m.ClientStreaming, err = messageType(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("returns"); err != nil {
+		return nil, err
+	}
+	if m.Output, m.ServerStreaming, err = messageType(); err != nil {
+		return nil, err
+	}
+
+	t := p.next()
+	switch {
+	case t.kind == tokenSymbol && t.text == ";":
+		return m, nil
+	case t.kind == tokenSymbol && t.text == "{":
+		for {
+			t := p.next()
+			switch {
+			case t.kind == tokenSymbol && t.text == "}":
+				return m, nil
+			case t.kind == tokenSymbol && t.text == ";":
+			case t.text == "option":
+				name, value, err := p.option()
+				if err != nil {
+					return nil, err
+				}
+				m.Deprecated = m.Deprecated || name == "deprecated" && value == "true"
+				if err := p.expect(";"); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, p.errorf(t, "expected 'option', found %s", t)
+			}
+		}
+	}
+	return nil, p.errorf(t, "expected ';' or '{', found %s", t)
+}
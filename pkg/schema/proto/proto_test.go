@@ -0,0 +1,253 @@
+package proto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mije/graphql-tools/pkg/schema/format"
+	"github.com/mije/graphql-tools/pkg/schema/validate"
+)
+
+func TestConvert(t *testing.T) {
+	testData := []struct {
+		name  string
+		input string
+		opts  Options
+		want  string
+	}{
+		{
+			name: "scalars and nullability",
+			input: `message M {
+  int32 a = 1;
+  int64 b = 2;
+  optional bool c = 3;
+  repeated double d = 4;
+  bytes e = 5 [json_name = "data"];
+}`,
+			want: `type Query {
+  """Placeholder, there are no query methods."""
+  _: Boolean
+}
+
+type M {
+  a: Int!
+  b: String!
+  c: Boolean
+  d: [Float!]!
+  data: String!
+}
+`,
+		},
+		{
+			name: "nested types and comments",
+			input: `// A user.
+message User {
+  Address address = 1; // trailing comments are not kept
+
+  /* Postal address. */
+  message Address {
+    Kind kind = 1;
+    enum Kind {
+      KIND_HOME = 0;
+      // Deprecated.
+      KIND_WORK = 1 [deprecated = true];
+    }
+  }
+}`,
+			opts: Options{TrimEnumPrefix: true},
+			want: `type Query {
+  """Placeholder, there are no query methods."""
+  _: Boolean
+}
+
+"""A user."""
+type User {
+  address: UserAddress
+}
+
+"""Postal address."""
+type UserAddress {
+  kind: UserAddressKind!
+}
+
+enum UserAddressKind {
+  HOME
+  """Deprecated."""
+  WORK @deprecated
+}
+`,
+		},
+		{
+			name: "maps, oneofs and well-known types",
+			input: `import "google/protobuf/timestamp.proto";
+message M {
+  map<string, int32> counts = 1;
+  oneof value {
+    string text = 2;
+    google.protobuf.Timestamp at = 3;
+  }
+  google.protobuf.Int32Value limit = 4;
+}
+message Empty {}`,
+			want: `type Query {
+  """Placeholder, there are no query methods."""
+  _: Boolean
+}
+
+type M {
+  counts: [MCountsEntry!]!
+  text: String
+  at: Timestamp
+  limit: Int
+}
+
+type MCountsEntry {
+  key: String!
+  value: Int!
+}
+
+"""Point in time encoded as an RFC 3339 string, google.protobuf.Timestamp."""
+scalar Timestamp
+
+type Empty {
+  """Placeholder, the message has no fields."""
+  _: Boolean
+}
+`,
+		},
+		{
+			name: "services",
+			input: `package shop.v1;
+import "google/protobuf/empty.proto";
+message Item { string item_id = 1; }
+message GetItemRequest { string item_id = 1; }
+message SaveItemRequest { Item item = 1; }
+service Items {
+  // Returns an item.
+  rpc GetItem(GetItemRequest) returns (.shop.v1.Item);
+  rpc SaveItem(SaveItemRequest) returns (Item) { option deprecated = true; }
+  rpc Clear(google.protobuf.Empty) returns (google.protobuf.Empty);
+  rpc Watch(GetItemRequest) returns (stream Item);
+  rpc Upload(stream Item) returns (Item);
+}`,
+			want: `type Query {
+  """Returns an item."""
+  getItem(itemId: String): Item
+}
+
+type Mutation {
+  saveItem(item: ItemInput): Item @deprecated
+  clear: Boolean
+}
+
+type Subscription {
+  watch(itemId: String): Item
+}
+
+type Item {
+  itemId: String!
+}
+
+input ItemInput {
+  itemId: String
+}
+`,
+		},
+		{
+			name:  "naming options",
+			input: `message Item { string item_id = 1; google.protobuf.StringValue name = 2; }`,
+			opts:  Options{FieldNames: "proto", TypePrefix: "Pb", Wrappers: true},
+			want: `type Query {
+  """Placeholder, there are no query methods."""
+  _: Boolean
+}
+
+type PbItem {
+  item_id: String!
+  name: PbStringValue
+}
+
+type PbStringValue {
+  value: String!
+}
+`,
+		},
+	}
+
+	for _, td := range testData {
+		t.Run(td.name, func(t *testing.T) {
+			f, err := Parse("test.proto", strings.NewReader("syntax = \"proto3\";\n"+td.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc, err := Convert([]*File{f}, nil, td.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range validate.Document(doc) {
+				t.Errorf("invalid schema: %v", e)
+			}
+			var buf bytes.Buffer
+			if err := format.Document(&buf, doc, format.Options{}); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != td.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, td.want)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	testData := []struct {
+		name  string
+		input string
+		opts  Options
+		want  string
+	}{
+		{
+			name:  "proto2",
+			input: `syntax = "proto2";`,
+			want:  "test.proto:1:10: syntax 'proto2' is not supported, only proto3 is",
+		},
+		{
+			name:  "missing semicolon",
+			input: "syntax = \"proto3\";\nmessage M { string a = 1 }",
+			want:  "test.proto:2:26: expected ';', found '}'",
+		},
+		{
+			name:  "unknown type",
+			input: `syntax = "proto3"; message M { Unknown a = 1; }`,
+			want:  "message 'M': field 'a': type 'Unknown' not found",
+		},
+		{
+			name:  "name clash",
+			input: `syntax = "proto3"; message A { message B {} } message AB {}`,
+			want:  "message 'A.B' and message 'AB' are both named 'AB'",
+		},
+		{
+			name:  "wrapper clash",
+			input: `syntax = "proto3"; message StringValue {} message M { google.protobuf.StringValue a = 1; StringValue b = 2; }`,
+			opts:  Options{Wrappers: true},
+			want:  "message 'M': field 'a': message 'StringValue' and message 'google.protobuf.StringValue' are both named 'StringValue'",
+		},
+		{
+			name:  "root clash",
+			input: `syntax = "proto3"; message Query {}`,
+			want:  "the root operation type and message 'Query' are both named 'Query'",
+		},
+	}
+
+	for _, td := range testData {
+		t.Run(td.name, func(t *testing.T) {
+			f, err := Parse("test.proto", strings.NewReader(td.input))
+			if err == nil {
+				_, err = Convert([]*File{f}, nil, td.opts)
+			}
+			if err == nil || err.Error() != td.want {
+				t.Errorf("got %v, want %s", err, td.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package prune
+
+import (
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Unreachable returns names of the types which cannot be reached from the root operation types
+// (Query, Mutation and Subscription unless the schema definition says otherwise) nor from the arguments
+// of the directive definitions. Types are reached by fields, arguments, implemented interfaces,
+// union members and, as they may be returned in place of reachable interfaces, by implementing them.
+func Unreachable(doc *ast.SchemaDocument) []string {
+	merged, _ := merge.Documents(doc)
+
+	types := make(map[string]*ast.Definition)
+	implementations := make(map[string][]string)
+	for _, def := range merged.Definitions {
+		types[def.Name] = def
+		for _, i := range def.Interfaces {
+			implementations[i] = append(implementations[i], def.Name)
+		}
+	}
+
+	reached := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		def, ok := types[name]
+		if !ok || reached[name] {
+			return
+		}
+		reached[name] = true
+
+		for _, f := range def.Fields {
+			visit(f.Type.Name())
+			for _, a := range f.Arguments {
+				visit(a.Type.Name())
+			}
+		}
+		for _, i := range def.Interfaces {
+			visit(i)
+		}
+		for _, t := range def.Types {
+			visit(t)
+		}
+		for _, t := range implementations[name] {
+			visit(t)
+		}
+	}
+
+	for _, name := range rootTypes(merged) {
+		visit(name)
+	}
+	for _, d := range merged.Directives {
+		for _, a := range d.Arguments {
+			visit(a.Type.Name())
+		}
+	}
+
+	var names []string
+	for _, def := range merged.Definitions {
+		if !reached[def.Name] {
+			names = append(names, def.Name)
+		}
+	}
+	return names
+}
+
+func rootTypes(doc *ast.SchemaDocument) []string {
+	var names []string
+	for _, def := range doc.Schema {
+		for _, op := range def.OperationTypes {
+			names = append(names, op.Type)
+		}
+	}
+	if len(doc.Schema) == 0 {
+		names = append(names, "Query", "Mutation", "Subscription")
+	}
+	return names
+}
+
+// Document removes the unreachable types, including their extensions, see Unreachable.
+// The given document is left intact, the pruned one is returned along with names of the removed types.
+func Document(doc *ast.SchemaDocument) (*ast.SchemaDocument, []string) {
+	names := Unreachable(doc)
+	removed := make(map[string]bool)
+	for _, name := range names {
+		removed[name] = true
+	}
+
+	pruned := *doc
+	pruned.Definitions, pruned.Extensions = nil, nil
+	for _, def := range doc.Definitions {
+		if !removed[def.Name] {
+			pruned.Definitions = append(pruned.Definitions, def)
+		}
+	}
+	for _, def := range doc.Extensions {
+		if !removed[def.Name] {
+			pruned.Extensions = append(pruned.Extensions, def)
+		}
+	}
+	return &pruned, names
+}
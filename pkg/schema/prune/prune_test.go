@@ -0,0 +1,99 @@
+package prune
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestUnreachable(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema string
+		want   []string
+	}{
+		{
+			name: "Types reachable from the root types are kept",
+			schema: `
+type Query { hero(episode: Episode, filter: Filter): Character search: SearchResult }
+interface Character { name: String }
+type Human implements Character { name: String }
+type Droid implements Character { name: String }
+union SearchResult = Starship
+type Starship { name: String }
+enum Episode { NEW_HOPE }
+input Filter { nested: NestedFilter }
+input NestedFilter { name: String }
+`,
+		},
+		{
+			name: "Orphaned types are reported",
+			schema: `
+type Query { hero: Hero }
+type Hero { name: String }
+type Villain { name: String lair: Lair }
+type Lair { name: String }
+enum Episode { NEW_HOPE }
+`,
+			want: []string{"Villain", "Lair", "Episode"},
+		},
+		{
+			name: "Custom root types and extensions are followed",
+			schema: `
+schema { query: Root }
+type Root { a: Int }
+extend type Root { hero: Hero }
+type Hero { name: String }
+type Query { a: Int }
+`,
+			want: []string{"Query"},
+		},
+		{
+			name: "Directive arguments reach types",
+			schema: `
+directive @key(fields: FieldSet!) on OBJECT
+scalar FieldSet
+scalar Unused
+type Query { a: Int }
+`,
+			want: []string{"Unused"},
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			doc, err := parser.ParseSchema(&ast.Source{Input: s.schema})
+			if err != nil {
+				t.Fatalf("unable to parse schema: %v", err)
+			}
+			if have := Unreachable(doc); !reflect.DeepEqual(s.want, have) {
+				t.Errorf("invalid types: want %q, have %q", s.want, have)
+			}
+		})
+	}
+}
+
+func TestDocument(t *testing.T) {
+	doc, err := parser.ParseSchema(&ast.Source{Input: `
+type Query { a: Int }
+type Orphan { a: Int }
+extend type Orphan { b: Int }
+extend type Query { b: Int }
+`})
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+
+	pruned, removed := Document(doc)
+	if !reflect.DeepEqual(removed, []string{"Orphan"}) {
+		t.Errorf("invalid removed types: %q", removed)
+	}
+	if len(pruned.Definitions) != 1 || len(pruned.Extensions) != 1 || pruned.Extensions[0].Name != "Query" {
+		t.Errorf("invalid pruned document: %d definitions, %d extensions", len(pruned.Definitions), len(pruned.Extensions))
+	}
+	if len(doc.Definitions) != 2 {
+		t.Error("original document must be left intact")
+	}
+}
@@ -0,0 +1,218 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+)
+
+var (
+	// ErrNotFound is returned when no schema version matches the request.
+	ErrNotFound = errors.New("schema version not found")
+
+	// ErrBreakingChanges is returned when publishing a schema with breaking changes which were not acknowledged.
+	ErrBreakingChanges = errors.New("schema contains breaking changes")
+
+	// ErrUnchanged is returned along with the latest version when publishing a schema identical to it.
+	ErrUnchanged = errors.New("schema is identical to the latest version")
+
+	validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+)
+
+const historyFile = "history.json"
+
+// Registry stores published schema versions per service and environment in a local directory.
+type Registry struct {
+	dir string
+}
+
+// Entry describes a published schema version.
+type Entry struct {
+
+	// Service the schema belongs to
+	Service string `json:"service"`
+
+	// Environment the schema is published to
+	Environment string `json:"environment"`
+
+	// Version is a sequence number of the schema, starting from 1
+	Version int `json:"version"`
+
+	// Published is the time of publication
+	Published time.Time `json:"published"`
+
+	// Digest is a SHA-256 checksum of the schema
+	Digest string `json:"digest"`
+
+	// Breaking indicates the version was published with acknowledged breaking changes
+	Breaking bool `json:"breaking,omitempty"`
+}
+
+// Open opens the registry stored in given directory, the directory is created if it does not exist.
+func Open(dir string) (*Registry, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to open registry '%s': %v", dir, err)
+	}
+	return &Registry{dir: dir}, nil
+}
+
+// History returns all versions published for the service and environment, oldest first.
+func (r *Registry) History(service, env string) ([]Entry, error) {
+	dir, err := r.path(service, env)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, historyFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history of '%s/%s': %v", service, env, err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("unable to read history of '%s/%s': %v", service, env, err)
+	}
+	return entries, nil
+}
+
+// Latest returns the most recently published version or ErrNotFound if nothing was published yet.
+func (r *Registry) Latest(service, env string) (*Entry, error) {
+	entries, err := r.History(service, env)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNotFound
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// Fetch returns the schema of given version, zero version stands for the latest one.
+func (r *Registry) Fetch(service, env string, version int) (*Entry, []byte, error) {
+	entries, err := r.History(service, env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var e *Entry
+	for i := range entries {
+		if version == 0 || entries[i].Version == version {
+			e = &entries[i]
+		}
+	}
+	if e == nil {
+		return nil, nil, ErrNotFound
+	}
+
+	dir, _ := r.path(service, env)
+	b, err := ioutil.ReadFile(filepath.Join(dir, schemaFile(e.Version)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read schema '%s/%s' version %d: %v", service, env, e.Version, err)
+	}
+	return e, b, nil
+}
+
+// Check compares the schema against the latest published version.
+// All changes are reported as additions when nothing was published yet.
+func (r *Registry) Check(service, env string, sdl []byte) (*compare.Result, error) {
+	var latest []byte
+	_, b, err := r.Fetch(service, env, 0)
+	switch err {
+	case nil:
+		latest = b
+	case ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	return compare.Schema(bytes.NewReader(latest), bytes.NewReader(sdl))
+}
+
+// Publish stores the schema as a new version unless it is identical to the latest one, the latest version
+// is returned with ErrUnchanged then. Publishing fails with ErrBreakingChanges if the schema breaks the latest version and
+// the breaking changes are not acknowledged.
+func (r *Registry) Publish(service, env string, sdl []byte, ackBreaking bool) (*Entry, *compare.Result, error) {
+	res, err := r.Check(service, env, sdl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, err := r.History(service, env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sum := sha256.Sum256(sdl)
+	digest := hex.EncodeToString(sum[:])
+	if n := len(entries); n > 0 && entries[n-1].Digest == digest {
+		return &entries[n-1], res, ErrUnchanged
+	}
+
+	breaking := len(res.Breaking()) > 0
+	if breaking && !ackBreaking {
+		return nil, res, ErrBreakingChanges
+	}
+
+	e := Entry{
+		Service:     service,
+		Environment: env,
+		Version:     len(entries) + 1,
+		Published:   time.Now().UTC(),
+		Digest:      digest,
+		Breaking:    breaking,
+	}
+
+	dir, _ := r.path(service, env)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("unable to publish schema '%s/%s': %v", service, env, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, schemaFile(e.Version)), sdl, 0644); err != nil {
+		return nil, nil, fmt.Errorf("unable to publish schema '%s/%s': %v", service, env, err)
+	}
+
+	b, err := json.MarshalIndent(append(entries, e), "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeFileAtomic(filepath.Join(dir, historyFile), b); err != nil {
+		return nil, nil, fmt.Errorf("unable to publish schema '%s/%s': %v", service, env, err)
+	}
+
+	return &e, res, nil
+}
+
+func (r *Registry) path(service, env string) (string, error) {
+	if !validName.MatchString(service) {
+		return "", fmt.Errorf("invalid service name '%s'", service)
+	}
+	if !validName.MatchString(env) {
+		return "", fmt.Errorf("invalid environment name '%s'", env)
+	}
+	return filepath.Join(r.dir, service, env), nil
+}
+
+func schemaFile(version int) string {
+	return strconv.Itoa(version) + ".graphql"
+}
+
+func writeFileAtomic(name string, b []byte) error {
+	tmp := name + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := Open(dir)
+	if err != nil {
+		t.Fatalf("unable to open registry: %v", err)
+	}
+
+	steps := []struct {
+		name        string
+		sdl         string
+		ackBreaking bool
+		wantErr     error
+		wantVersion int
+	}{
+		{
+			name:        "First version is published",
+			sdl:         "type Query { a: String }",
+			wantVersion: 1,
+		},
+		{
+			name:        "Non-breaking change is published",
+			sdl:         "type Query { a: String b: Int }",
+			wantVersion: 2,
+		},
+		{
+			name:        "Identical schema is not published again",
+			sdl:         "type Query { a: String b: Int }",
+			wantErr:     ErrUnchanged,
+			wantVersion: 2,
+		},
+		{
+			name:    "Breaking change is refused",
+			sdl:     "type Query { b: Int }",
+			wantErr: ErrBreakingChanges,
+		},
+		{
+			name:        "Acknowledged breaking change is published",
+			sdl:         "type Query { b: Int }",
+			ackBreaking: true,
+			wantVersion: 3,
+		},
+	}
+
+	for _, s := range steps {
+		e, _, err := r.Publish("starwars", "prod", []byte(s.sdl), s.ackBreaking)
+		if err != s.wantErr {
+			t.Fatalf("%s: invalid error: want %v, have %v", s.name, s.wantErr, err)
+		}
+		if (err == nil || err == ErrUnchanged) && e.Version != s.wantVersion {
+			t.Errorf("%s: invalid version: want %d, have %d", s.name, s.wantVersion, e.Version)
+		}
+	}
+
+	entries, err := r.History("starwars", "prod")
+	if err != nil {
+		t.Fatalf("unable to read history: %v", err)
+	}
+	if len(entries) != 3 || !entries[2].Breaking {
+		t.Errorf("invalid history: %+v", entries)
+	}
+
+	if _, b, err := r.Fetch("starwars", "prod", 1); err != nil || string(b) != steps[0].sdl {
+		t.Errorf("invalid schema version 1: %q, %v", b, err)
+	}
+	if _, _, err := r.Fetch("starwars", "dev", 0); err != ErrNotFound {
+		t.Errorf("invalid error for unknown environment: %v", err)
+	}
+	if _, err := r.History("../starwars", "prod"); err == nil {
+		t.Error("invalid service name should be rejected")
+	}
+}
@@ -0,0 +1,272 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/mije/graphql-tools/pkg/schema/merge"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Stats are metrics of a schema.
+type Stats struct {
+
+	// Types counts type definitions per kind
+	Types map[ast.DefinitionKind]int `json:"types"`
+
+	// Directives counts directive definitions
+	Directives int `json:"directives"`
+
+	// Fields counts fields of objects and interfaces and fields of input objects
+	Fields int `json:"fields"`
+
+	// Arguments counts arguments of fields and directive definitions
+	Arguments int `json:"arguments"`
+
+	// EnumValues counts values of enums
+	EnumValues int `json:"enumValues"`
+
+	// Deprecated counts fields, arguments and enum values marked by @deprecated
+	Deprecated int `json:"deprecated"`
+
+	// MaxDepth is the number of levels of fields needed to reach the most distant type from the root types,
+	// the root types themselves being at depth 1
+	MaxDepth int `json:"maxDepth"`
+
+	// FanOut maps types to the number of distinct types referenced by their fields, arguments,
+	// implemented interfaces and union members
+	FanOut map[string]int `json:"fanOut"`
+
+	// Cycles lists groups of types referencing each other
+	Cycles [][]string `json:"cycles"`
+
+	// Descriptions measures how many types, fields, arguments, enum values and directives are described
+	Descriptions Coverage `json:"descriptions"`
+}
+
+// Coverage is a number of described items out of the total number of items.
+type Coverage struct {
+	Described int `json:"described"`
+	Total     int `json:"total"`
+}
+
+// Percent returns the coverage in percents, a schema without any items is fully covered.
+func (c Coverage) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return float64(c.Described) * 100 / float64(c.Total)
+}
+
+func (c *Coverage) add(description string) {
+	c.Total++
+	if description != "" {
+		c.Described++
+	}
+}
+
+// Document computes metrics of the schema, extensions included.
+func Document(doc *ast.SchemaDocument) *Stats {
+	doc, _ = merge.Documents(doc)
+
+	s := &Stats{
+		Types:  make(map[ast.DefinitionKind]int),
+		FanOut: make(map[string]int),
+		Cycles: [][]string{},
+	}
+
+	countArguments := func(args ast.ArgumentDefinitionList) {
+		for _, a := range args {
+			s.Arguments++
+			s.Descriptions.add(a.Description)
+			if a.Directives.ForName("deprecated") != nil {
+				s.Deprecated++
+			}
+		}
+	}
+
+	for _, d := range doc.Directives {
+		s.Directives++
+		s.Descriptions.add(d.Description)
+		countArguments(d.Arguments)
+	}
+
+	graph := make(map[string][]string)
+	implementations := make(map[string][]string)
+	for _, def := range doc.Definitions {
+		s.Types[def.Kind]++
+		s.Descriptions.add(def.Description)
+
+		for _, f := range def.Fields {
+			s.Fields++
+			s.Descriptions.add(f.Description)
+			if f.Directives.ForName("deprecated") != nil {
+				s.Deprecated++
+			}
+			countArguments(f.Arguments)
+		}
+		for _, v := range def.EnumValues {
+			s.EnumValues++
+			s.Descriptions.add(v.Description)
+			if v.Directives.ForName("deprecated") != nil {
+				s.Deprecated++
+			}
+		}
+
+		graph[def.Name] = references(def)
+		for _, i := range def.Interfaces {
+			implementations[i] = append(implementations[i], def.Name)
+		}
+		if def.Kind != ast.Scalar && def.Kind != ast.Enum {
+			s.FanOut[def.Name] = len(graph[def.Name])
+		}
+	}
+
+	s.MaxDepth = maxDepth(doc, graph, implementations)
+	s.Cycles = append(s.Cycles, cycles(doc, graph)...)
+	return s
+}
+
+// references returns the distinct types referenced by the definition.
+func references(def *ast.Definition) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, f := range def.Fields {
+		add(f.Type.Name())
+		for _, a := range f.Arguments {
+			add(a.Type.Name())
+		}
+	}
+	for _, name := range def.Interfaces {
+		add(name)
+	}
+	for _, name := range def.Types {
+		add(name)
+	}
+	return names
+}
+
+// maxDepth returns the depth of the type most distant from the root types, following the shortest paths.
+// Implementations of an interface are at the depth of the interface as they may be returned in its place.
+func maxDepth(doc *ast.SchemaDocument, graph, implementations map[string][]string) int {
+	var roots []string
+	for _, def := range doc.Schema {
+		for _, op := range def.OperationTypes {
+			roots = append(roots, op.Type)
+		}
+	}
+	if len(doc.Schema) == 0 {
+		roots = []string{"Query", "Mutation", "Subscription"}
+	}
+
+	depth := make(map[string]int)
+	var queue []string
+	for _, r := range roots {
+		if _, ok := graph[r]; ok && depth[r] == 0 {
+			depth[r] = 1
+			queue = append(queue, r)
+		}
+	}
+
+	max := 0
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if depth[name] > max {
+			max = depth[name]
+		}
+		for _, impl := range implementations[name] {
+			if depth[impl] == 0 {
+				depth[impl] = depth[name]
+				queue = append(queue, impl)
+			}
+		}
+		for _, ref := range graph[name] {
+			if _, ok := graph[ref]; ok && depth[ref] == 0 {
+				depth[ref] = depth[name] + 1
+				queue = append(queue, ref)
+			}
+		}
+	}
+	return max
+}
+
+// cycles returns the strongly connected components of the type graph which form a cycle,
+// see https://en.wikipedia.org/wiki/Tarjan%27s_strongly_connected_components_algorithm.
+func cycles(doc *ast.SchemaDocument, graph map[string][]string) [][]string {
+	var (
+		index    = make(map[string]int)
+		lowLink  = make(map[string]int)
+		onStack  = make(map[string]bool)
+		stack    []string
+		counter  int
+		found    [][]string
+		connect  func(name string)
+		hasCycle = func(c []string) bool {
+			if len(c) > 1 {
+				return true
+			}
+			for _, ref := range graph[c[0]] {
+				if ref == c[0] {
+					return true
+				}
+			}
+			return false
+		}
+	)
+
+	connect = func(name string) {
+		counter++
+		index[name], lowLink[name] = counter, counter
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, ref := range graph[name] {
+			if _, ok := graph[ref]; !ok {
+				continue
+			}
+			if index[ref] == 0 {
+				connect(ref)
+				if lowLink[ref] < lowLink[name] {
+					lowLink[name] = lowLink[ref]
+				}
+			} else if onStack[ref] && index[ref] < lowLink[name] {
+				lowLink[name] = index[ref]
+			}
+		}
+
+		if lowLink[name] == index[name] {
+			var c []string
+			for {
+				n := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[n] = false
+				c = append(c, n)
+				if n == name {
+					break
+				}
+			}
+			if hasCycle(c) {
+				sort.Strings(c)
+				found = append(found, c)
+			}
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		if index[def.Name] == 0 {
+			connect(def.Name)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i][0] < found[j][0]
+	})
+	return found
+}
@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+func TestDocument(t *testing.T) {
+	doc, err := parser.ParseSchema(&ast.Source{Input: `
+"Custom directive"
+directive @auth(role: String) on FIELD_DEFINITION
+
+type Query {
+  "The hero"
+  hero(episode: Episode): Character
+  droid(id: ID!): Droid @deprecated
+}
+
+"A character"
+interface Character { name: String friends: [Character] }
+type Human implements Character { name: String friends: [Character] starship: Starship }
+type Droid implements Character { name: String friends: [Character] }
+type Starship { name: String pilot: Human }
+union SearchResult = Human | Droid
+enum Episode { NEW_HOPE EMPIRE @deprecated }
+extend enum Episode { JEDI }
+input Filter { name: String }
+scalar Date
+`})
+	if err != nil {
+		t.Fatalf("unable to parse schema: %v", err)
+	}
+
+	want := &Stats{
+		Types: map[ast.DefinitionKind]int{
+			ast.Object: 4, ast.Interface: 1, ast.Union: 1, ast.Enum: 1, ast.InputObject: 1, ast.Scalar: 1,
+		},
+		Directives: 1,
+		Fields:     12,
+		Arguments:  3,
+		EnumValues: 3,
+		Deprecated: 2,
+		MaxDepth:   3,
+		FanOut: map[string]int{
+			"Query": 4, "Character": 2, "Human": 3, "Droid": 2, "Starship": 2, "SearchResult": 2, "Filter": 1,
+		},
+		Cycles:       [][]string{{"Character"}, {"Human", "Starship"}},
+		Descriptions: Coverage{Described: 3, Total: 28},
+	}
+	if have := Document(doc); !reflect.DeepEqual(want, have) {
+		t.Errorf("invalid stats:\nwant %+v\nhave %+v", want, have)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	testData := []struct {
+		name   string
+		schema string
+		want   [][]string
+	}{
+		{
+			name:   "Acyclic schema",
+			schema: `type Query { a: A } type A { b: B } type B { name: String }`,
+			want:   [][]string{},
+		},
+		{
+			name:   "Self reference",
+			schema: `type Query { a: A } type A { parent: A }`,
+			want:   [][]string{{"A"}},
+		},
+		{
+			name:   "Separate cycles",
+			schema: `type Query { a: A c: C } type A { b: B } type B { a: A } type C { d: D } type D { c: C } input I { i: I }`,
+			want:   [][]string{{"A", "B"}, {"C", "D"}, {"I"}},
+		},
+	}
+
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			doc, err := parser.ParseSchema(&ast.Source{Input: s.schema})
+			if err != nil {
+				t.Fatalf("unable to parse schema: %v", err)
+			}
+			if have := Document(doc).Cycles; !reflect.DeepEqual(s.want, have) {
+				t.Errorf("invalid cycles: want %q, have %q", s.want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+var locations = map[ast.DirectiveLocation]bool{
+	ast.LocationQuery:                true,
+	ast.LocationMutation:             true,
+	ast.LocationSubscription:         true,
+	ast.LocationField:                true,
+	ast.LocationFragmentDefinition:   true,
+	ast.LocationFragmentSpread:       true,
+	ast.LocationInlineFragment:       true,
+	ast.LocationSchema:               true,
+	ast.LocationScalar:               true,
+	ast.LocationObject:               true,
+	ast.LocationFieldDefinition:      true,
+	ast.LocationArgumentDefinition:   true,
+	ast.LocationInterface:            true,
+	ast.LocationUnion:                true,
+	ast.LocationEnum:                 true,
+	ast.LocationEnumValue:            true,
+	ast.LocationInputObject:          true,
+	ast.LocationInputFieldDefinition: true,
+}
+
+func (v *validator) validateDirectiveDefinition(def *ast.DirectiveDefinition) {
+	v.validateName(def.Position, "Directive", def.Name)
+
+	if len(def.Locations) == 0 {
+		v.report(def.Position, "Directive '%s' must define one or more locations", def.Name)
+	}
+	seen := make(map[ast.DirectiveLocation]bool)
+	for _, loc := range def.Locations {
+		switch {
+		case !locations[loc]:
+			v.report(def.Position, "Directive '%s' has unknown location '%s'", def.Name, loc)
+		case seen[loc]:
+			v.report(def.Position, "Directive '%s' includes location '%s' more than once", def.Name, loc)
+		}
+		seen[loc] = true
+	}
+
+	v.validateArguments("directive '@"+def.Name+"'", def.Arguments)
+	for _, arg := range def.Arguments {
+		for _, d := range arg.Directives {
+			if d.Name == def.Name {
+				v.report(d.Position, "Directive '%s' cannot reference itself", def.Name)
+			}
+		}
+	}
+}
+
+// validateDirectives checks the directives applied at the given location.
+func (v *validator) validateDirectives(dirs ast.DirectiveList, loc ast.DirectiveLocation) {
+	seen := make(map[string]bool)
+	for _, d := range dirs {
+		def, ok := v.directives[d.Name]
+		if !ok {
+			v.report(d.Position, "Directive '@%s' is not defined", d.Name)
+			continue
+		}
+
+		if seen[d.Name] {
+			v.report(d.Position, "Directive '@%s' can be used only once at %s", d.Name, loc)
+		}
+		seen[d.Name] = true
+
+		allowed := false
+		for _, l := range def.Locations {
+			allowed = allowed || l == loc
+		}
+		if !allowed {
+			v.report(d.Position, "Directive '@%s' cannot be used at %s", d.Name, loc)
+		}
+
+		v.validateDirectiveArguments(d, def)
+	}
+}
+
+func (v *validator) validateDirectiveArguments(d *ast.Directive, def *ast.DirectiveDefinition) {
+	seen := make(map[string]bool)
+	for _, arg := range d.Arguments {
+		if seen[arg.Name] {
+			v.report(arg.Position, "Argument '%s' of directive '@%s' is provided more than once", arg.Name, d.Name)
+			continue
+		}
+		seen[arg.Name] = true
+
+		argDef := def.Arguments.ForName(arg.Name)
+		if argDef == nil {
+			v.report(arg.Position, "Directive '@%s' has no argument '%s'", d.Name, arg.Name)
+			continue
+		}
+		v.validateValue(arg.Position, fmt.Sprintf("Argument '%s' of directive '@%s'", arg.Name, d.Name), arg.Value, argDef.Type)
+	}
+
+	for _, argDef := range def.Arguments {
+		if argDef.Type.NonNull && argDef.DefaultValue == nil && !seen[argDef.Name] {
+			v.report(d.Position, "Directive '@%s' requires argument '%s'", d.Name, argDef.Name)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package validate
+
+import (
+	"github.com/vektah/gqlparser/ast"
+)
+
+func (v *validator) validateEnum(def *ast.Definition) {
+	if len(def.EnumValues) == 0 {
+		v.report(def.Position, "Enum '%s' must define one or more values", def.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, val := range def.EnumValues {
+		v.validateName(val.Position, "Enum value", val.Name)
+		switch {
+		case val.Name == "true" || val.Name == "false" || val.Name == "null":
+			v.report(val.Position, "Enum '%s' cannot define value '%s'", def.Name, val.Name)
+		case seen[val.Name]:
+			v.report(val.Position, "Enum value '%s.%s' is already defined", def.Name, val.Name)
+		}
+		seen[val.Name] = true
+
+		v.validateDirectives(val.Directives, ast.LocationEnumValue)
+	}
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+func (v *validator) validateInput(def *ast.Definition) {
+	if len(def.Fields) == 0 {
+		v.report(def.Position, "Input object '%s' must define one or more fields", def.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range def.Fields {
+		what := fmt.Sprintf("Input field '%s.%s'", def.Name, f.Name)
+		v.validateName(f.Position, "Input field", f.Name)
+		if seen[f.Name] {
+			v.report(f.Position, "%s is already defined", what)
+		}
+		seen[f.Name] = true
+
+		if len(f.Arguments) > 0 {
+			v.report(f.Position, "%s cannot define arguments", what)
+		}
+		if v.validateTypeRef(f.Position, what, f.Type, inputKinds) && f.DefaultValue != nil {
+			v.validateValue(f.Position, "Default value of "+lowerFirst(what), f.DefaultValue, f.Type)
+		}
+		v.validateDirectives(f.Directives, ast.LocationInputFieldDefinition)
+	}
+}
+
+// validateInputCycles reports input objects which cannot be provided because they reference themselves
+// through non-null fields.
+func (v *validator) validateInputCycles(defs []*ast.Definition) {
+	reported := make(map[string]bool)
+	for _, def := range defs {
+		if def.Kind != ast.InputObject || reported[def.Name] {
+			continue
+		}
+		if path := v.findInputCycle(def, def.Name, nil, make(map[string]bool)); path != nil {
+			for _, p := range path {
+				reported[strings.Split(p, ".")[0]] = true
+			}
+			v.report(def.Position, "Input object '%s' cannot reference itself through non-null fields: %s", def.Name, strings.Join(path, ", "))
+		}
+	}
+}
+
+func (v *validator) findInputCycle(def *ast.Definition, target string, path []string, visited map[string]bool) []string {
+	visited[def.Name] = true
+	for _, f := range def.Fields {
+		if !f.Type.NonNull || f.Type.Elem != nil {
+			continue
+		}
+		next := append(append([]string(nil), path...), def.Name+"."+f.Name)
+		if f.Type.NamedType == target {
+			return next
+		}
+		ref, ok := v.types[f.Type.NamedType]
+		if !ok || ref.Kind != ast.InputObject || visited[ref.Name] {
+			continue
+		}
+		if cycle := v.findInputCycle(ref, target, next, visited); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+func (v *validator) validateObject(def *ast.Definition) {
+	kind := "Type"
+	if def.Kind == ast.Interface {
+		kind = "Interface"
+	}
+	if len(def.Fields) == 0 {
+		v.report(def.Position, "%s '%s' must define one or more fields", kind, def.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range def.Fields {
+		what := fmt.Sprintf("Field '%s.%s'", def.Name, f.Name)
+		v.validateName(f.Position, "Field", f.Name)
+		if seen[f.Name] {
+			v.report(f.Position, "%s is already defined", what)
+		}
+		seen[f.Name] = true
+
+		v.validateTypeRef(f.Position, what, f.Type, outputKinds)
+		v.validateArguments("field '"+def.Name+"."+f.Name+"'", f.Arguments)
+		v.validateDirectives(f.Directives, ast.LocationFieldDefinition)
+	}
+
+	implemented := make(map[string]bool)
+	for _, name := range def.Interfaces {
+		if implemented[name] {
+			v.report(def.Position, "%s '%s' implements interface '%s' more than once", kind, def.Name, name)
+			continue
+		}
+		implemented[name] = true
+		v.validateImplementation(def, name)
+	}
+}
+
+// validateImplementation checks the rules of https://spec.graphql.org/June2018/#sec-Objects.Type-Validation.
+func (v *validator) validateImplementation(def *ast.Definition, name string) {
+	inf, ok := v.types[name]
+	if !ok {
+		v.report(def.Position, "Type '%s' implements undefined interface '%s'", def.Name, name)
+		return
+	}
+	if inf.Kind != ast.Interface {
+		v.report(def.Position, "Type '%s' implements '%s' which is %s, not %s", def.Name, name, inf.Kind, ast.Interface)
+		return
+	}
+
+	for _, want := range inf.Fields {
+		have := def.Fields.ForName(want.Name)
+		if have == nil {
+			v.report(def.Position, "Type '%s' must define field '%s' required by interface '%s'", def.Name, want.Name, name)
+			continue
+		}
+
+		if !v.isSubType(have.Type, want.Type) {
+			v.report(have.Position, "Field '%s.%s' must be of type '%s' or its sub-type as required by interface '%s'", def.Name, have.Name, want.Type, name)
+		}
+
+		for _, wantArg := range want.Arguments {
+			haveArg := have.Arguments.ForName(wantArg.Name)
+			if haveArg == nil {
+				v.report(have.Position, "Field '%s.%s' must define argument '%s' required by interface '%s'", def.Name, have.Name, wantArg.Name, name)
+				continue
+			}
+			if haveArg.Type.String() != wantArg.Type.String() {
+				v.report(haveArg.Position, "Argument '%s' of field '%s.%s' must be of type '%s' as required by interface '%s'", haveArg.Name, def.Name, have.Name, wantArg.Type, name)
+			}
+		}
+
+		for _, haveArg := range have.Arguments {
+			if want.Arguments.ForName(haveArg.Name) == nil && haveArg.Type.NonNull && haveArg.DefaultValue == nil {
+				v.report(haveArg.Position, "Argument '%s' of field '%s.%s' must be optional because it is not defined by interface '%s'", haveArg.Name, def.Name, have.Name, name)
+			}
+		}
+	}
+}
+
+// isSubType reports whether the field type x is a valid implementation of the interface field type y.
+func (v *validator) isSubType(x, y *ast.Type) bool {
+	if y.NonNull && !x.NonNull {
+		return false
+	}
+	if y.Elem != nil {
+		return x.Elem != nil && v.isSubType(x.Elem, y.Elem)
+	}
+	if x.Elem != nil {
+		return false
+	}
+	if x.NamedType == y.NamedType {
+		return true
+	}
+
+	xdef, ydef := v.types[x.NamedType], v.types[y.NamedType]
+	if xdef == nil || ydef == nil || xdef.Kind != ast.Object {
+		return false
+	}
+	switch ydef.Kind {
+	case ast.Interface:
+		for _, name := range xdef.Interfaces {
+			if name == ydef.Name {
+				return true
+			}
+		}
+	case ast.Union:
+		for _, name := range ydef.Types {
+			if name == xdef.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"github.com/vektah/gqlparser/ast"
+)
+
+func (v *validator) validateSchema(doc *ast.SchemaDocument) {
+	defined := make(map[string]bool)
+	var defs []*ast.Definition
+	for _, def := range doc.Definitions {
+		if defined[def.Name] {
+			v.report(def.Position, "Type '%s' is already defined", def.Name)
+			continue
+		}
+		defined[def.Name] = true
+
+		// definitions are copied to not modify the document while applying extensions
+		cp := *def
+		v.types[def.Name] = &cp
+		defs = append(defs, &cp)
+	}
+
+	for _, ext := range doc.Extensions {
+		v.validateExtension(ext)
+	}
+
+	definedDirectives := make(map[string]bool)
+	var dirs []*ast.DirectiveDefinition
+	for _, def := range doc.Directives {
+		if definedDirectives[def.Name] {
+			v.report(def.Position, "Directive '%s' is already defined", def.Name)
+			continue
+		}
+		definedDirectives[def.Name] = true
+		v.directives[def.Name] = def
+		dirs = append(dirs, def)
+	}
+
+	v.validateRootTypes(doc)
+
+	for _, def := range dirs {
+		v.validateDirectiveDefinition(def)
+	}
+
+	for _, def := range defs {
+		v.validateType(def)
+	}
+
+	v.validateInputCycles(defs)
+}
+
+func (v *validator) validateExtension(ext *ast.Definition) {
+	def, ok := v.types[ext.Name]
+	if !ok {
+		v.report(ext.Position, "Cannot extend type '%s' because it is not defined", ext.Name)
+		return
+	}
+	if def.Kind != ext.Kind {
+		v.report(ext.Position, "Cannot extend type '%s' because it is %s, not %s", ext.Name, def.Kind, ext.Kind)
+		return
+	}
+
+	cp := *def
+	cp.Directives = append(append(ast.DirectiveList(nil), def.Directives...), ext.Directives...)
+	cp.Interfaces = append(append([]string(nil), def.Interfaces...), ext.Interfaces...)
+	cp.Fields = append(append(ast.FieldList(nil), def.Fields...), ext.Fields...)
+	cp.Types = append(append([]string(nil), def.Types...), ext.Types...)
+	cp.EnumValues = append(append(ast.EnumValueList(nil), def.EnumValues...), ext.EnumValues...)
+	*def = cp
+}
+
+func (v *validator) validateRootTypes(doc *ast.SchemaDocument) {
+	schemaDefs := doc.Schema
+	if len(schemaDefs) > 1 {
+		v.report(schemaDefs[1].Position, "Schema must be defined only once, use schema extensions instead")
+		schemaDefs = schemaDefs[:1]
+	}
+
+	roots := make(map[ast.Operation]*ast.OperationTypeDefinition)
+	for _, list := range []ast.SchemaDefinitionList{schemaDefs, doc.SchemaExtension} {
+		for _, def := range list {
+			v.validateDirectives(def.Directives, ast.LocationSchema)
+			for _, op := range def.OperationTypes {
+				if _, ok := roots[op.Operation]; ok {
+					v.report(op.Position, "Root %s type is already defined", op.Operation)
+					continue
+				}
+				roots[op.Operation] = op
+			}
+		}
+	}
+
+	if len(doc.Schema) == 0 && len(doc.SchemaExtension) == 0 {
+		for op, name := range map[ast.Operation]string{
+			ast.Query:        "Query",
+			ast.Mutation:     "Mutation",
+			ast.Subscription: "Subscription",
+		} {
+			if def, ok := v.types[name]; ok {
+				roots[op] = &ast.OperationTypeDefinition{Operation: op, Type: name, Position: def.Position}
+			}
+		}
+	}
+
+	if _, ok := roots[ast.Query]; !ok {
+		v.report(doc.Position, "Root query type must be provided")
+	}
+
+	for _, op := range []ast.Operation{ast.Query, ast.Mutation, ast.Subscription} {
+		root, ok := roots[op]
+		if !ok {
+			continue
+		}
+		def, ok := v.types[root.Type]
+		if !ok {
+			v.report(root.Position, "Root %s type '%s' is not defined", op, root.Type)
+			continue
+		}
+		if def.Kind != ast.Object {
+			v.report(root.Position, "Root %s type '%s' must be %s, not %s", op, root.Type, ast.Object, def.Kind)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"github.com/vektah/gqlparser/ast"
+)
+
+var (
+	inputKinds  = []ast.DefinitionKind{ast.Scalar, ast.Enum, ast.InputObject}
+	outputKinds = []ast.DefinitionKind{ast.Scalar, ast.Object, ast.Interface, ast.Union, ast.Enum}
+)
+
+func (v *validator) validateType(def *ast.Definition) {
+	v.validateName(def.Position, "Type", def.Name)
+
+	switch def.Kind {
+	case ast.Scalar:
+		v.validateDirectives(def.Directives, ast.LocationScalar)
+	case ast.Object:
+		v.validateDirectives(def.Directives, ast.LocationObject)
+		v.validateObject(def)
+	case ast.Interface:
+		v.validateDirectives(def.Directives, ast.LocationInterface)
+		v.validateObject(def)
+	case ast.Union:
+		v.validateDirectives(def.Directives, ast.LocationUnion)
+		v.validateUnion(def)
+	case ast.Enum:
+		v.validateDirectives(def.Directives, ast.LocationEnum)
+		v.validateEnum(def)
+	case ast.InputObject:
+		v.validateDirectives(def.Directives, ast.LocationInputObject)
+		v.validateInput(def)
+	}
+}
+
+// validateTypeRef checks the referenced type is defined and has one of the given kinds.
+func (v *validator) validateTypeRef(pos *ast.Position, what string, typ *ast.Type, kinds []ast.DefinitionKind) bool {
+	def, ok := v.types[typ.Name()]
+	if !ok {
+		v.report(pos, "%s has undefined type '%s'", what, typ.Name())
+		return false
+	}
+	for _, k := range kinds {
+		if def.Kind == k {
+			return true
+		}
+	}
+	v.report(pos, "%s must be an %s type, '%s' is %s", what, positionName(kinds), typ.Name(), def.Kind)
+	return false
+}
+
+func positionName(kinds []ast.DefinitionKind) string {
+	if len(kinds) == len(inputKinds) {
+		return "input"
+	}
+	return "output"
+}
+
+func (v *validator) validateArguments(what string, args ast.ArgumentDefinitionList) {
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		argWhat := "Argument '" + arg.Name + "' of " + what
+		v.validateName(arg.Position, "Argument", arg.Name)
+		if seen[arg.Name] {
+			v.report(arg.Position, "%s is already defined", argWhat)
+		}
+		seen[arg.Name] = true
+
+		if v.validateTypeRef(arg.Position, argWhat, arg.Type, inputKinds) && arg.DefaultValue != nil {
+			v.validateValue(arg.Position, "Default value of "+lowerFirst(argWhat), arg.DefaultValue, arg.Type)
+		}
+		v.validateDirectives(arg.Directives, ast.LocationArgumentDefinition)
+	}
+}
@@ -0,0 +1,29 @@
+package validate
+
+import (
+	"github.com/vektah/gqlparser/ast"
+)
+
+func (v *validator) validateUnion(def *ast.Definition) {
+	if len(def.Types) == 0 {
+		v.report(def.Position, "Union '%s' must define one or more member types", def.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range def.Types {
+		if seen[name] {
+			v.report(def.Position, "Union '%s' includes member type '%s' more than once", def.Name, name)
+			continue
+		}
+		seen[name] = true
+
+		member, ok := v.types[name]
+		if !ok {
+			v.report(def.Position, "Union '%s' has undefined member type '%s'", def.Name, name)
+			continue
+		}
+		if member.Kind != ast.Object {
+			v.report(def.Position, "Union '%s' member type '%s' must be %s, not %s", def.Name, name, ast.Object, member.Kind)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+// Schema validates a GraphQL schema encoded using SDL against the rules of the specification.
+// Syntax errors are reported as validation errors as well, the returned error indicates
+// the schema could not be read.
+func Schema(name string, r io.Reader) ([]Error, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read schema '%s': %v", name, err)
+	}
+
+	doc, gqlErr := parser.ParseSchema(&ast.Source{Name: name, Input: string(b)})
+	if gqlErr != nil {
+		e := Error{Message: gqlErr.Message}
+		if len(gqlErr.Locations) > 0 {
+			e.Position = &ast.Position{
+				Line:   gqlErr.Locations[0].Line,
+				Column: gqlErr.Locations[0].Column,
+				Src:    &ast.Source{Name: name},
+			}
+		}
+		return []Error{e}, nil
+	}
+
+	return Document(doc), nil
+}
+
+// Document validates an already parsed schema, see Schema.
+func Document(doc *ast.SchemaDocument) []Error {
+	v := newValidator()
+	v.validateSchema(doc)
+	return v.errs
+}
+
+// Error is a violation of a validation rule.
+type Error struct {
+
+	// Message provides human-readable explanation of the violation
+	Message string `json:"message"`
+
+	// Position of the invalid item in the source, if known
+	Position *ast.Position `json:"-"`
+}
+
+func (e Error) Error() string {
+	if e.Position == nil {
+		return e.Message
+	}
+
+	var loc []string
+	if e.Position.Src != nil && e.Position.Src.Name != "" {
+		loc = append(loc, e.Position.Src.Name)
+	}
+	loc = append(loc, fmt.Sprint(e.Position.Line), fmt.Sprint(e.Position.Column))
+	return strings.Join(loc, ":") + ": " + e.Message
+}
+
+const builtIns = `
+scalar Int
+scalar Float
+scalar String
+scalar Boolean
+scalar ID
+directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
+directive @deprecated(reason: String = "No longer supported") on FIELD_DEFINITION | ARGUMENT_DEFINITION | INPUT_FIELD_DEFINITION | ENUM_VALUE
+directive @specifiedBy(url: String!) on SCALAR
+`
+
+type validator struct {
+	types      map[string]*ast.Definition
+	directives map[string]*ast.DirectiveDefinition
+	errs       []Error
+}
+
+func newValidator() *validator {
+	doc, err := parser.ParseSchema(&ast.Source{Name: "built-ins", Input: builtIns, BuiltIn: true})
+	if err != nil {
+		panic(fmt.Errorf("invalid built-ins: %v", err))
+	}
+
+	v := &validator{
+		types:      make(map[string]*ast.Definition),
+		directives: make(map[string]*ast.DirectiveDefinition),
+	}
+	for _, def := range doc.Definitions {
+		v.types[def.Name] = def
+	}
+	for _, def := range doc.Directives {
+		v.directives[def.Name] = def
+	}
+	return v
+}
+
+func (v *validator) report(pos *ast.Position, format string, args ...interface{}) {
+	v.errs = append(v.errs, Error{
+		Message:  fmt.Sprintf(format, args...),
+		Position: pos,
+	})
+}
+
+func (v *validator) validateName(pos *ast.Position, what, name string) {
+	if strings.HasPrefix(name, "__") {
+		v.report(pos, "%s '%s' must not begin with '__', which is reserved by GraphQL introspection", what, name)
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,231 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	testData := map[string][]struct {
+		name   string
+		schema string
+		want   string
+	}{
+		"Schema": {
+			{
+				name:   "Valid schema has no errors",
+				schema: "type Query { a(x: Int = 1): String @deprecated } enum E { X } input I { e: E = X }",
+			},
+			{
+				name:   "Syntax error is reported",
+				schema: "type Query {",
+				want:   "test.graphql:1:13: Expected Name, found <EOF>",
+			},
+			{
+				name:   "Query type must be provided",
+				schema: "type A { a: String }",
+				want:   "Root query type must be provided",
+			},
+			{
+				name:   "Root type must be an object",
+				schema: "schema { query: Q } scalar Q",
+				want:   "Root query type 'Q' must be OBJECT, not SCALAR",
+			},
+			{
+				name:   "Root type must be defined",
+				schema: "schema { query: Q }",
+				want:   "Root query type 'Q' is not defined",
+			},
+			{
+				name:   "Schema can be defined only once",
+				schema: "schema { query: Query } schema { query: Query } type Query { a: String }",
+				want:   "Schema must be defined only once",
+			},
+		},
+		"Type": {
+			{
+				name:   "Type can be defined only once",
+				schema: "type Query { a: String } type Query { b: String }",
+				want:   "Type 'Query' is already defined",
+			},
+			{
+				name:   "Type name must not begin with __",
+				schema: "type Query { a: String } scalar __A",
+				want:   "Type '__A' must not begin with '__'",
+			},
+			{
+				name:   "Extended type must be defined",
+				schema: "type Query { a: String } extend type A { a: String }",
+				want:   "Cannot extend type 'A' because it is not defined",
+			},
+			{
+				name:   "Extended type must be of same kind",
+				schema: "type Query { a: String } extend interface Query { b: String }",
+				want:   "Cannot extend type 'Query' because it is OBJECT, not INTERFACE",
+			},
+		},
+		"Object": {
+			{
+				name:   "Field type must be defined",
+				schema: "type Query { a: A }",
+				want:   "Field 'Query.a' has undefined type 'A'",
+			},
+			{
+				name:   "Field type must be an output type",
+				schema: "type Query { a: I } input I { i: Int }",
+				want:   "Field 'Query.a' must be an output type, 'I' is INPUT_OBJECT",
+			},
+			{
+				name:   "Field can be defined only once",
+				schema: "type Query { a: String } extend type Query { a: Int }",
+				want:   "Field 'Query.a' is already defined",
+			},
+			{
+				name:   "Argument must be an input type",
+				schema: "type Query { a(q: Query): String }",
+				want:   "Argument 'q' of field 'Query.a' must be an input type, 'Query' is OBJECT",
+			},
+			{
+				name:   "Argument default value must match the type",
+				schema: "type Query { a(x: Int = \"1\"): String }",
+				want:   "Default value of argument 'x' of field 'Query.a' has invalid value \"1\", expected type 'Int'",
+			},
+		},
+		"Interface": {
+			{
+				name:   "Implemented interface must be defined",
+				schema: "type Query implements I { a: String }",
+				want:   "Type 'Query' implements undefined interface 'I'",
+			},
+			{
+				name:   "Implemented type must be an interface",
+				schema: "type Query implements A { a: String } type A { a: String }",
+				want:   "Type 'Query' implements 'A' which is OBJECT, not INTERFACE",
+			},
+			{
+				name:   "Implementation must define interface fields",
+				schema: "type Query implements I { a: String } interface I { b: String }",
+				want:   "Type 'Query' must define field 'b' required by interface 'I'",
+			},
+			{
+				name:   "Implementation field must be a sub-type",
+				schema: "type Query implements I { a: Int } interface I { a: String! }",
+				want:   "Field 'Query.a' must be of type 'String!' or its sub-type as required by interface 'I'",
+			},
+			{
+				name:   "Implementation field can be a covariant type",
+				schema: "type Query implements I { a: [Query!]! } interface I { a: [I] }",
+			},
+			{
+				name:   "Implementation field must define interface arguments",
+				schema: "type Query implements I { a: String } interface I { a(x: Int): String }",
+				want:   "Field 'Query.a' must define argument 'x' required by interface 'I'",
+			},
+			{
+				name:   "Implementation field additional arguments must be optional",
+				schema: "type Query implements I { a(x: Int!): String } interface I { a: String }",
+				want:   "Argument 'x' of field 'Query.a' must be optional because it is not defined by interface 'I'",
+			},
+		},
+		"Union": {
+			{
+				name:   "Union member must be an object",
+				schema: "type Query { u: U } union U = Query | S scalar S",
+				want:   "Union 'U' member type 'S' must be OBJECT, not SCALAR",
+			},
+			{
+				name:   "Union member must be defined",
+				schema: "type Query { u: U } union U = A",
+				want:   "Union 'U' has undefined member type 'A'",
+			},
+		},
+		"Enum": {
+			{
+				name:   "Enum value can be defined only once",
+				schema: "type Query { e: E } enum E { X X }",
+				want:   "Enum value 'E.X' is already defined",
+			},
+			{
+				name:   "Enum cannot define true",
+				schema: "type Query { e: E } enum E { true }",
+				want:   "Enum 'E' cannot define value 'true'",
+			},
+		},
+		"Input": {
+			{
+				name:   "Input field must be an input type",
+				schema: "type Query { a(i: I): String } input I { q: Query }",
+				want:   "Input field 'I.q' must be an input type, 'Query' is OBJECT",
+			},
+			{
+				name:   "Input object cannot reference itself through non-null fields",
+				schema: "type Query { a(i: I): String } input I { j: J! } input J { i: I! }",
+				want:   "Input object 'I' cannot reference itself through non-null fields: I.j, J.i",
+			},
+			{
+				name:   "Input object can reference itself through nullable fields",
+				schema: "type Query { a(i: I): String } input I { i: I j: [I!]! }",
+			},
+			{
+				name:   "Input default value must provide required fields",
+				schema: "type Query { a(i: I = {}): String } input I { x: Int! }",
+				want:   "Default value of argument 'i' of field 'Query.a' is missing required field 'x' of input object 'I'",
+			},
+		},
+		"Directive": {
+			{
+				name:   "Directive must be defined",
+				schema: "type Query { a: String @auth }",
+				want:   "Directive '@auth' is not defined",
+			},
+			{
+				name:   "Directive must be used at allowed location",
+				schema: "type Query @deprecated { a: String }",
+				want:   "Directive '@deprecated' cannot be used at OBJECT",
+			},
+			{
+				name:   "Directive must be provided with required arguments",
+				schema: "directive @auth(role: String!) on FIELD_DEFINITION type Query { a: String @auth }",
+				want:   "Directive '@auth' requires argument 'role'",
+			},
+			{
+				name:   "Directive argument must be defined",
+				schema: "type Query { a: String @deprecated(why: \"x\") }",
+				want:   "Directive '@deprecated' has no argument 'why'",
+			},
+			{
+				name:   "Directive cannot be repeated",
+				schema: "type Query { a: String @deprecated @deprecated }",
+				want:   "Directive '@deprecated' can be used only once at FIELD_DEFINITION",
+			},
+			{
+				name:   "Directive cannot reference itself",
+				schema: "directive @a(x: Int @a) on ARGUMENT_DEFINITION type Query { a: String }",
+				want:   "Directive 'a' cannot reference itself",
+			},
+		},
+	}
+
+	for category, scenarios := range testData {
+		for _, s := range scenarios {
+			t.Run(category+"/"+s.name, func(t *testing.T) {
+				errs, err := Schema("test.graphql", strings.NewReader(s.schema))
+				if err != nil {
+					t.Fatalf("unable to validate schema: %v", err)
+				}
+				if s.want == "" {
+					for _, e := range errs {
+						t.Errorf("unexpected error: %v", e)
+					}
+					return
+				}
+				if len(errs) != 1 {
+					t.Fatalf("invalid number of errors: want 1, have %d: %v", len(errs), errs)
+				}
+				if have := errs[0].Error(); !strings.Contains(have, s.want) {
+					t.Errorf("invalid error: want %q, have %q", s.want, have)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/vektah/gqlparser/ast"
+)
+
+// validateValue checks the literal can be coerced to the given input type.
+func (v *validator) validateValue(pos *ast.Position, what string, val *ast.Value, typ *ast.Type) {
+	if val.Position != nil {
+		pos = val.Position
+	}
+
+	if val.Kind == ast.Variable {
+		v.report(pos, "%s cannot use variables", what)
+		return
+	}
+
+	if val.Kind == ast.NullValue {
+		if typ.NonNull {
+			v.report(pos, "%s cannot be null, expected type '%s'", what, typ)
+		}
+		return
+	}
+
+	if typ.Elem != nil {
+		if val.Kind != ast.ListValue { // single values are coerced to lists
+			v.validateValue(pos, what, val, typ.Elem)
+			return
+		}
+		for _, c := range val.Children {
+			v.validateValue(pos, what, c.Value, typ.Elem)
+		}
+		return
+	}
+
+	def, ok := v.types[typ.NamedType]
+	if !ok {
+		return // undefined type is reported already
+	}
+
+	switch def.Kind {
+	case ast.Scalar:
+		if !isValidScalar(def.Name, val) {
+			v.report(pos, "%s has invalid value %s, expected type '%s'", what, val, typ)
+		}
+	case ast.Enum:
+		if val.Kind != ast.EnumValue || def.EnumValues.ForName(val.Raw) == nil {
+			v.report(pos, "%s has invalid value %s, expected one of the values of enum '%s'", what, val, def.Name)
+		}
+	case ast.InputObject:
+		if val.Kind != ast.ObjectValue {
+			v.report(pos, "%s has invalid value %s, expected input object '%s'", what, val, def.Name)
+			return
+		}
+		for _, c := range val.Children {
+			f := def.Fields.ForName(c.Name)
+			if f == nil {
+				v.report(pos, "%s has unknown field '%s' of input object '%s'", what, c.Name, def.Name)
+				continue
+			}
+			v.validateValue(pos, what, c.Value, f.Type)
+		}
+		for _, f := range def.Fields {
+			if f.Type.NonNull && f.DefaultValue == nil && val.Children.ForName(f.Name) == nil {
+				v.report(pos, "%s is missing required field '%s' of input object '%s'", what, f.Name, def.Name)
+			}
+		}
+	}
+}
+
+func isValidScalar(name string, val *ast.Value) bool {
+	switch name {
+	case "Int":
+		n, err := strconv.ParseInt(val.Raw, 10, 64)
+		return val.Kind == ast.IntValue && err == nil && n >= math.MinInt32 && n <= math.MaxInt32
+	case "Float":
+		return val.Kind == ast.IntValue || val.Kind == ast.FloatValue
+	case "String":
+		return val.Kind == ast.StringValue || val.Kind == ast.BlockValue
+	case "Boolean":
+		return val.Kind == ast.BooleanValue
+	case "ID":
+		return val.Kind == ast.StringValue || val.Kind == ast.IntValue
+	default:
+		return true // custom scalars accept any literal
+	}
+}
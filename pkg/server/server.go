@@ -0,0 +1,220 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mije/graphql-tools/pkg/schema/compare"
+	"github.com/mije/graphql-tools/pkg/schema/introspection"
+	"github.com/mije/graphql-tools/pkg/schema/lint"
+	"github.com/mije/graphql-tools/pkg/schema/validate"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/parser"
+)
+
+const maxRequestSize = 10 << 20
+
+// NewHandler returns a handler exposing the schema tools as JSON endpoints:
+//
+//	POST /compare   {"old": Source, "new": Source} -> compare.Result
+//	POST /validate  Source -> ValidateResponse
+//	POST /lint      LintRequest -> LintResponse
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/compare", post(handleCompare))
+	mux.HandleFunc("/validate", post(handleValidate))
+	mux.HandleFunc("/lint", post(handleLint))
+	return mux
+}
+
+// Source is a schema encoded either using SDL or as an introspection query result.
+type Source struct {
+	SDL           string          `json:"sdl,omitempty"`
+	Introspection json.RawMessage `json:"introspection,omitempty"`
+}
+
+func (s Source) document() (*ast.SchemaDocument, error) {
+	switch {
+	case s.SDL != "" && len(s.Introspection) > 0:
+		return nil, fmt.Errorf("either sdl or introspection must be provided, not both")
+	case len(s.Introspection) > 0:
+		is, err := introspection.Unmarshal(s.Introspection)
+		if err != nil {
+			return nil, err
+		}
+		return is.Document()
+	default:
+		doc, err := parser.ParseSchema(&ast.Source{Input: s.SDL})
+		if err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+}
+
+// CompareRequest is the body of the compare endpoint.
+type CompareRequest struct {
+	Old Source `json:"old"`
+	New Source `json:"new"`
+
+	// Validate both schemas before comparing them
+	Validate bool `json:"validate,omitempty"`
+}
+
+func handleCompare(w http.ResponseWriter, r *http.Request) {
+	var req CompareRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	x, err := req.Old.document()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid old schema: %v", err))
+		return
+	}
+	y, err := req.New.document()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid new schema: %v", err))
+		return
+	}
+
+	if req.Validate {
+		for _, s := range []struct {
+			name string
+			doc  *ast.SchemaDocument
+		}{{"old", x}, {"new", y}} {
+			if errs := validate.Document(s.doc); len(errs) > 0 {
+				writeJSON(w, http.StatusUnprocessableEntity, struct {
+					Error  string          `json:"error"`
+					Errors []ValidateError `json:"errors"`
+				}{"invalid " + s.name + " schema", validateResponse(errs).Errors})
+				return
+			}
+		}
+	}
+
+	res, err := compare.Documents(x, y)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// ValidateResponse is the body returned by the validate endpoint.
+type ValidateResponse struct {
+	Valid  bool            `json:"valid"`
+	Errors []ValidateError `json:"errors"`
+}
+
+// ValidateError is a validation error located in the schema source.
+type ValidateError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+func handleValidate(w http.ResponseWriter, r *http.Request) {
+	var req Source
+	if !decode(w, r, &req) {
+		return
+	}
+
+	var errs []validate.Error
+	if len(req.Introspection) > 0 {
+		doc, err := req.document()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		errs = validate.Document(doc)
+	} else {
+		var err error
+		if errs, err = validate.Schema("sdl", strings.NewReader(req.SDL)); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, validateResponse(errs))
+}
+
+func validateResponse(errs []validate.Error) ValidateResponse {
+	res := ValidateResponse{Valid: len(errs) == 0, Errors: []ValidateError{}}
+	for _, e := range errs {
+		ve := ValidateError{Message: e.Message}
+		if e.Position != nil {
+			ve.Line, ve.Column = e.Position.Line, e.Position.Column
+		}
+		res.Errors = append(res.Errors, ve)
+	}
+	return res
+}
+
+// LintRequest is the body of the lint endpoint.
+type LintRequest struct {
+	Source
+	Config lint.Config `json:"config"`
+}
+
+// LintResponse is the body returned by the lint endpoint.
+type LintResponse struct {
+	Diagnostics []lint.Diagnostic `json:"diagnostics"`
+}
+
+func handleLint(w http.ResponseWriter, r *http.Request) {
+	var req LintRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	doc, err := req.document()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	diagnostics, err := lint.Document(doc, req.Config)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if diagnostics == nil {
+		diagnostics = []lint.Diagnostic{}
+	}
+	writeJSON(w, http.StatusOK, LintResponse{Diagnostics: diagnostics})
+}
+
+func post(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		h(w, r)
+	}
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) // nolint: errcheck
+}
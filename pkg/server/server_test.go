@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	testData := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "Compare SDL schemas",
+			method:     http.MethodPost,
+			path:       "/compare",
+			body:       `{"old": {"sdl": "type Query { a: String }"}, "new": {"sdl": "type Query { b: String }"}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"type":"OBJECT_TYPE_FIELD_REMOVED"`,
+		},
+		{
+			name:   "Compare SDL with introspection",
+			method: http.MethodPost,
+			path:   "/compare",
+			body: `{"old": {"sdl": "type Query { a: String }"}, "new": {"introspection": {"__schema": {"queryType": {"name": "Query"}, "types": [
+				{"kind": "OBJECT", "name": "Query", "fields": [{"name": "a", "args": [], "type": {"kind": "SCALAR", "name": "String"}}]}
+			]}}}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"breaking":[],"dangerous":[],"nonBreaking":[]}`,
+		},
+		{
+			name:       "Compare invalid schema",
+			method:     http.MethodPost,
+			path:       "/compare",
+			body:       `{"old": {"sdl": "type Query {"}, "new": {"sdl": ""}}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `invalid old schema`,
+		},
+		{
+			name:       "Compare validates schemas on request",
+			method:     http.MethodPost,
+			path:       "/compare",
+			body:       `{"old": {"sdl": "type Query { a: String }"}, "new": {"sdl": "type Query { a: A }"}, "validate": true}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `"message":"Field 'Query.a' has undefined type 'A'"`,
+		},
+		{
+			name:       "Compare requires POST",
+			method:     http.MethodGet,
+			path:       "/compare",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "Validate valid schema",
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"sdl": "type Query { a: String }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"valid":true`,
+		},
+		{
+			name:       "Validate invalid schema",
+			method:     http.MethodPost,
+			path:       "/validate",
+			body:       `{"sdl": "type Query { a: Undefined }"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"valid":false,"errors":[{"message":"Field 'Query.a' has undefined type 'Undefined'","line":1,"column":14}]}`,
+		},
+		{
+			name:       "Lint schema",
+			method:     http.MethodPost,
+			path:       "/lint",
+			body:       `{"sdl": "type query { a: String }", "config": {"rules": {"type-description-required": "off"}}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"diagnostics":[{"rule":"type-name-pascal-case","severity":"error","message":"Type name 'query' must be PascalCase","path":"query","line":1,"column":6}]}`,
+		},
+		{
+			name:       "Lint with invalid config",
+			method:     http.MethodPost,
+			path:       "/lint",
+			body:       `{"sdl": "type Query { a: String }", "config": {"rules": {"type-name-pascal-case": "fatal"}}}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	h := NewHandler()
+	for _, s := range testData {
+		t.Run(s.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(s.method, s.path, strings.NewReader(s.body)))
+			if w.Code != s.wantStatus {
+				t.Errorf("invalid status: want %d, have %d", s.wantStatus, w.Code)
+			}
+			if !json.Valid(w.Body.Bytes()) {
+				t.Errorf("invalid JSON: %s", w.Body)
+			}
+			if !strings.Contains(w.Body.String(), s.wantBody) {
+				t.Errorf("invalid body: want %s, have %s", s.wantBody, w.Body)
+			}
+		})
+	}
+}